@@ -0,0 +1,176 @@
+// Package api serves cost estimates over HTTP as JSON.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/royge/gostcalc/currency"
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+)
+
+const (
+	// DefaultTimeout is the default time limit of a request.
+	DefaultTimeout = 10 * time.Second
+
+	// MaxBodySize is the largest accepted request body in bytes.
+	MaxBodySize = 1 << 20
+)
+
+// Server handles the estimate API.
+type Server struct {
+	// Prices is the price book used for every estimate.
+	Prices firestore.PriceList
+
+	// Rate is the exchange rate of Prices from the list price currency.
+	Rate currency.Rate
+
+	// Timeout limits the time spent on each request.
+	Timeout time.Duration
+}
+
+// Currency describes the currency of the amounts of a response.
+type Currency struct {
+	Code     string `json:"code"`
+	Rate     string `json:"rate,omitempty"`
+	RateDate string `json:"rate_date,omitempty"`
+}
+
+// EstimateResponse is the itemized monthly estimate of a scenario.
+type EstimateResponse struct {
+	Name     string                                `json:"name"`
+	Currency Currency                              `json:"currency"`
+	Costs    map[scenario.Category]firestore.Money `json:"costs"`
+	Total    firestore.Money                       `json:"total"`
+}
+
+// PricesResponse is the price book of the server.
+type PricesResponse struct {
+	Currency Currency            `json:"currency"`
+	Prices   firestore.PriceList `json:"prices"`
+}
+
+// ErrorResponse describes a failed request.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handler returns the HTTP handler of the API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/estimate", s.post(s.estimate))
+	mux.HandleFunc("/v1/prices", s.get(s.prices))
+	mux.HandleFunc("/v1/document/size", s.post(s.documentSize))
+	mux.HandleFunc("/openapi.json", s.get(openAPI))
+
+	return mux
+}
+
+type handlerFunc func(ctx context.Context, r *http.Request) (interface{}, error)
+
+// errBadRequest marks errors caused by invalid requests.
+var errBadRequest = errors.New("bad request")
+
+func (s *Server) post(h handlerFunc) http.HandlerFunc {
+	return s.handle(http.MethodPost, h)
+}
+
+func (s *Server) get(h handlerFunc) http.HandlerFunc {
+	return s.handle(http.MethodGet, h)
+}
+
+func (s *Server) handle(method string, h handlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			write(w, http.StatusMethodNotAllowed, ErrorResponse{Error: "method not allowed"})
+			return
+		}
+
+		timeout := s.Timeout
+		if timeout == 0 {
+			timeout = DefaultTimeout
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		r.Body = http.MaxBytesReader(w, r.Body, MaxBodySize)
+
+		res, err := h(ctx, r)
+		switch {
+		case err == nil:
+			write(w, http.StatusOK, res)
+		case errors.Is(err, errBadRequest):
+			write(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, context.DeadlineExceeded):
+			write(w, http.StatusGatewayTimeout, ErrorResponse{Error: "estimate timed out"})
+		default:
+			write(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		}
+	}
+}
+
+func (s *Server) estimate(ctx context.Context, r *http.Request) (interface{}, error) {
+	sc, err := scenario.Read(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errBadRequest, err)
+	}
+
+	e, err := sc.Estimate(ctx, s.Prices)
+	if err != nil {
+		return nil, err
+	}
+
+	return EstimateResponse{
+		Name:     sc.Name,
+		Currency: s.currency(),
+		Costs:    e,
+		Total:    e.Total(),
+	}, nil
+}
+
+func (s *Server) prices(_ context.Context, _ *http.Request) (interface{}, error) {
+	return PricesResponse{Currency: s.currency(), Prices: s.Prices}, nil
+}
+
+func (s *Server) documentSize(_ context.Context, r *http.Request) (interface{}, error) {
+	doc, err := scenario.ReadDocument(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errBadRequest, err)
+	}
+
+	return doc.Breakdown(), nil
+}
+
+func openAPI(_ context.Context, _ *http.Request) (interface{}, error) {
+	return json.RawMessage(OpenAPI), nil
+}
+
+func (s *Server) currency() Currency {
+	c := Currency{Code: s.Rate.To}
+	if c.Code == "" {
+		c.Code = currency.Base
+	}
+
+	if s.Rate.Rate != nil {
+		c.Rate = s.Rate.Rate.FloatString(6)
+		c.RateDate = s.Rate.Date.Format(currency.DateLayout)
+	}
+
+	return c
+}
+
+func write(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
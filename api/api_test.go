@@ -0,0 +1,140 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/royge/gostcalc/api"
+	"github.com/royge/gostcalc/firestore"
+)
+
+func serve(t *testing.T, s *api.Server, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	s.Handler().ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestServer_Estimate(t *testing.T) {
+	s := &api.Server{Prices: firestore.DefaultPrices}
+
+	rec := serve(t, s, http.MethodPost, "/v1/estimate", `{"name": "qr", "population": 1000000}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("want status %v, got %v: %s", http.StatusOK, rec.Code, rec.Body)
+	}
+
+	var res api.EstimateResponse
+	if err := json.NewDecoder(rec.Body).Decode(&res); err != nil {
+		t.Fatalf("unable to decode response: %v", err)
+	}
+
+	if want := 53892 * firestore.Cent; res.Costs["Write"] != want {
+		t.Errorf("want write cost %v, got %v", want, res.Costs["Write"])
+	}
+
+	if res.Currency.Code != "USD" {
+		t.Errorf("want currency USD, got %v", res.Currency.Code)
+	}
+}
+
+func TestServer_Estimate_Invalid(t *testing.T) {
+	s := &api.Server{Prices: firestore.DefaultPrices}
+
+	tt := []struct {
+		name   string
+		method string
+		body   string
+		want   int
+	}{
+		{"negative population", http.MethodPost, `{"population": -1}`, http.StatusBadRequest},
+		{"malformed json", http.MethodPost, `{`, http.StatusBadRequest},
+		{"wrong method", http.MethodGet, ``, http.StatusMethodNotAllowed},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serve(t, s, tc.method, "/v1/estimate", tc.body)
+			if rec.Code != tc.want {
+				t.Errorf("want status %v, got %v", tc.want, rec.Code)
+			}
+		})
+	}
+}
+
+func TestServer_Estimate_Timeout(t *testing.T) {
+	s := &api.Server{Prices: firestore.DefaultPrices, Timeout: time.Nanosecond}
+
+	rec := serve(t, s, http.MethodPost, "/v1/estimate", `{}`)
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Errorf("want status %v, got %v", http.StatusGatewayTimeout, rec.Code)
+	}
+}
+
+func TestServer_DocumentSize(t *testing.T) {
+	s := &api.Server{Prices: firestore.DefaultPrices}
+
+	rec := serve(t, s, http.MethodPost, "/v1/document/size", `{
+		"id": "my_task_id",
+		"collection": "users/jeff/tasks",
+		"data": {
+			"type": "Personal",
+			"done": false,
+			"priority": 1,
+			"description": "Learn Cloud Firestore",
+			"created": "2026-10-01T00:00:00Z"
+		},
+		"composite_indexes": [{"done": false, "priority": 1}]
+	}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("want status %v, got %v: %s", http.StatusOK, rec.Code, rec.Body)
+	}
+
+	var res firestore.SizeBreakdown
+	if err := json.NewDecoder(rec.Body).Decode(&res); err != nil {
+		t.Fatalf("unable to decode response: %v", err)
+	}
+
+	if want := int64(163 + 112); res.Total != want {
+		t.Errorf("want total size %v, got %v", want, res.Total)
+	}
+}
+
+func TestServer_Prices(t *testing.T) {
+	s := &api.Server{Prices: firestore.DefaultPrices}
+
+	rec := serve(t, s, http.MethodGet, "/v1/prices", "")
+
+	var res api.PricesResponse
+	if err := json.NewDecoder(rec.Body).Decode(&res); err != nil {
+		t.Fatalf("unable to decode response: %v", err)
+	}
+
+	if res.Prices != firestore.DefaultPrices {
+		t.Errorf("want default prices, got %+v", res.Prices)
+	}
+}
+
+func TestServer_OpenAPI(t *testing.T) {
+	s := &api.Server{}
+
+	rec := serve(t, s, http.MethodGet, "/openapi.json", "")
+
+	var spec map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&spec); err != nil {
+		t.Fatalf("unable to decode OpenAPI description: %v", err)
+	}
+
+	if spec["openapi"] != "3.0.3" {
+		t.Errorf("want OpenAPI 3.0.3, got %v", spec["openapi"])
+	}
+}
@@ -0,0 +1,181 @@
+package api
+
+// OpenAPI is the OpenAPI 3 description of the API.
+const OpenAPI = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "gostcalc",
+    "description": "Google Cloud Firestore cost estimates.",
+    "version": "1.0.0"
+  },
+  "paths": {
+    "/v1/estimate": {
+      "post": {
+        "summary": "Estimate the monthly costs of a scenario.",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {"$ref": "#/components/schemas/Scenario"}
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "Itemized monthly estimate.",
+            "content": {
+              "application/json": {
+                "schema": {"$ref": "#/components/schemas/Estimate"}
+              }
+            }
+          },
+          "400": {"$ref": "#/components/responses/Error"},
+          "504": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/prices": {
+      "get": {
+        "summary": "Get the price book used for estimates.",
+        "responses": {
+          "200": {
+            "description": "Unit price of every SKU.",
+            "content": {
+              "application/json": {
+                "schema": {"$ref": "#/components/schemas/Prices"}
+              }
+            }
+          }
+        }
+      }
+    },
+    "/v1/document/size": {
+      "post": {
+        "summary": "Calculate the stored size of a document.",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {"$ref": "#/components/schemas/Document"}
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "Size of every part of the document in bytes.",
+            "content": {
+              "application/json": {
+                "schema": {"$ref": "#/components/schemas/DocumentSize"}
+              }
+            }
+          },
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    }
+  },
+  "components": {
+    "responses": {
+      "Error": {
+        "description": "The request failed.",
+        "content": {
+          "application/json": {
+            "schema": {
+              "type": "object",
+              "properties": {"error": {"type": "string"}}
+            }
+          }
+        }
+      }
+    },
+    "schemas": {
+      "Money": {
+        "type": "string",
+        "description": "Exact decimal amount.",
+        "example": "538.80"
+      },
+      "Currency": {
+        "type": "object",
+        "properties": {
+          "code": {"type": "string", "example": "USD"},
+          "rate": {"type": "string"},
+          "rate_date": {"type": "string", "format": "date"}
+        }
+      },
+      "Document": {
+        "type": "object",
+        "required": ["collection"],
+        "properties": {
+          "id": {"type": "string"},
+          "collection": {"type": "string", "example": "profiles/AbCdEfGhIjKlMnOpQrSt/logs"},
+          "data": {
+            "type": "object",
+            "description": "Fields and sample values. RFC 3339 strings are timestamps.",
+            "additionalProperties": true
+          },
+          "single_field_indexes": {
+            "type": "array",
+            "items": {"type": "object", "additionalProperties": true}
+          },
+          "composite_indexes": {
+            "type": "array",
+            "items": {"type": "object", "additionalProperties": true}
+          }
+        }
+      },
+      "Scenario": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string"},
+          "population": {"type": "integer", "minimum": 0, "default": 1000000},
+          "count": {"type": "integer", "minimum": 0, "default": 10},
+          "writes": {"type": "number", "minimum": 0, "default": 1},
+          "reads": {"type": "number", "minimum": 0, "default": 1},
+          "deletes": {"type": "number", "minimum": 0, "default": 1},
+          "document_size": {"type": "integer", "minimum": 0},
+          "ttl_days": {"type": "integer", "minimum": 0},
+          "queried_fields": {"type": "array", "items": {"type": "string"}},
+          "document": {"$ref": "#/components/schemas/Document"}
+        }
+      },
+      "Estimate": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string"},
+          "currency": {"$ref": "#/components/schemas/Currency"},
+          "costs": {
+            "type": "object",
+            "additionalProperties": {"$ref": "#/components/schemas/Money"}
+          },
+          "total": {"$ref": "#/components/schemas/Money"}
+        }
+      },
+      "Prices": {
+        "type": "object",
+        "properties": {
+          "currency": {"$ref": "#/components/schemas/Currency"},
+          "prices": {
+            "type": "object",
+            "properties": {
+              "write": {"$ref": "#/components/schemas/Money"},
+              "read": {"$ref": "#/components/schemas/Money"},
+              "delete": {"$ref": "#/components/schemas/Money"},
+              "storage": {"$ref": "#/components/schemas/Money"},
+              "ingress": {"$ref": "#/components/schemas/Money"}
+            }
+          }
+        }
+      },
+      "DocumentSize": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "integer"},
+          "data": {"type": "integer"},
+          "single_field_indexes": {"type": "integer"},
+          "composite_indexes": {"type": "integer"},
+          "total": {"type": "integer"}
+        }
+      }
+    }
+  }
+}`
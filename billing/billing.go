@@ -0,0 +1,396 @@
+// Package billing reads Cloud Billing export rows of Firestore and reconciles
+// them with estimates.
+package billing
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+)
+
+// Service is the billing service description of Firestore.
+const Service = "Cloud Firestore"
+
+// MonthLayout is the layout of billing months.
+const MonthLayout = "2006-01"
+
+// ErrFormat is returned for billing files that cannot be read.
+var ErrFormat = errors.New("invalid billing export")
+
+// Row is a billing export row.
+type Row struct {
+	Service  string
+	SKU      string
+	Project  string
+	Resource string
+	Labels   map[string]string
+
+	// Month is the invoice month, e.g. 2026-03.
+	Month string
+
+	// Cost is the cost after credits.
+	Cost     firestore.Money
+	Currency string
+}
+
+// record is a row of a BigQuery billing export as newline-delimited JSON.
+type record struct {
+	Service struct {
+		Description string `json:"description"`
+	} `json:"service"`
+	SKU struct {
+		Description string `json:"description"`
+	} `json:"sku"`
+	Project struct {
+		ID string `json:"id"`
+	} `json:"project"`
+	Resource struct {
+		Name string `json:"name"`
+	} `json:"resource"`
+	Labels       []label `json:"labels"`
+	SystemLabels []label `json:"system_labels"`
+	Invoice      struct {
+		Month string `json:"month"`
+	} `json:"invoice"`
+	UsageStartTime string      `json:"usage_start_time"`
+	Cost           json.Number `json:"cost"`
+	Currency       string      `json:"currency"`
+	Credits        []struct {
+		Amount json.Number `json:"amount"`
+	} `json:"credits"`
+}
+
+type label struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// row converts r into a Row.
+func (r *record) row() (Row, error) {
+	cost, err := firestore.ParseMoney(r.Cost.String())
+	if err != nil {
+		return Row{}, err
+	}
+
+	for _, c := range r.Credits {
+		credit, err := firestore.ParseMoney(c.Amount.String())
+		if err != nil {
+			return Row{}, err
+		}
+		cost += credit
+	}
+
+	month, err := invoiceMonth(r.Invoice.Month, r.UsageStartTime)
+	if err != nil {
+		return Row{}, err
+	}
+
+	labels := map[string]string{}
+	for _, l := range append(r.SystemLabels, r.Labels...) {
+		labels[l.Key] = l.Value
+	}
+
+	return Row{
+		Service:  r.Service.Description,
+		SKU:      r.SKU.Description,
+		Project:  r.Project.ID,
+		Resource: r.Resource.Name,
+		Labels:   labels,
+		Month:    month,
+		Cost:     cost,
+		Currency: r.Currency,
+	}, nil
+}
+
+// Format a YYYYMM invoice month, or the month of the usage start time when
+// there is none.
+func invoiceMonth(invoice, start string) (string, error) {
+	if invoice != "" {
+		t, err := time.Parse("200601", invoice)
+		if err != nil {
+			return "", err
+		}
+		return t.Format(MonthLayout), nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, strings.Replace(start, " ", "T", 1))
+	if err != nil {
+		// BigQuery dumps timestamps as "2026-03-01 00:00:00 UTC".
+		t, err = time.Parse("2006-01-02 15:04:05 MST", start)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return t.UTC().Format(MonthLayout), nil
+}
+
+// ReadNDJSON reads newline-delimited JSON rows.
+func ReadNDJSON(r io.Reader) ([]Row, error) {
+	var rows []Row
+
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	for line := 1; s.Scan(); line++ {
+		b := strings.TrimSpace(s.Text())
+		if b == "" {
+			continue
+		}
+
+		var rec record
+		d := json.NewDecoder(strings.NewReader(b))
+		d.UseNumber()
+		if err := d.Decode(&rec); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrFormat, line, err)
+		}
+
+		row, err := rec.row()
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrFormat, line, err)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, s.Err()
+}
+
+// ReadCSV reads rows from a CSV dump with flattened column names, e.g.
+// service.description, sku.description, project.id, invoice.month, cost,
+// currency. Optional labels, system_labels and credits columns are JSON.
+func ReadCSV(r io.Reader) ([]Row, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFormat, err)
+	}
+
+	cols := map[string]int{}
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, c := range []string{"service.description", "sku.description", "cost"} {
+		if _, ok := cols[c]; !ok {
+			return nil, fmt.Errorf("%w: missing %s column", ErrFormat, c)
+		}
+	}
+
+	var rows []Row
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrFormat, err)
+		}
+
+		cell := func(c string) string {
+			i, ok := cols[c]
+			if !ok || i >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[i])
+		}
+
+		var r record
+		r.Service.Description = cell("service.description")
+		r.SKU.Description = cell("sku.description")
+		r.Project.ID = cell("project.id")
+		r.Resource.Name = cell("resource.name")
+		r.Invoice.Month = cell("invoice.month")
+		r.UsageStartTime = cell("usage_start_time")
+		r.Cost = json.Number(cell("cost"))
+		r.Currency = cell("currency")
+
+		for c, v := range map[string]interface{}{
+			"labels":        &r.Labels,
+			"system_labels": &r.SystemLabels,
+			"credits":       &r.Credits,
+		} {
+			if s := cell(c); s != "" {
+				d := json.NewDecoder(strings.NewReader(s))
+				d.UseNumber()
+				if err := d.Decode(v); err != nil {
+					return nil, fmt.Errorf("%w: line %d: %s: %v", ErrFormat, line, c, err)
+				}
+			}
+		}
+
+		row, err := r.row()
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrFormat, line, err)
+		}
+		rows = append(rows, row)
+	}
+}
+
+// Load reads the rows of a billing export file, newline-delimited JSON for
+// the .json and .ndjson extensions and CSV otherwise.
+func Load(path string) ([]Row, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	read := ReadCSV
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".ndjson", ".jsonl":
+		read = ReadNDJSON
+	}
+
+	rows, err := read(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return rows, nil
+}
+
+// Category returns the estimate category of a Firestore SKU description, and
+// false for SKUs that are not modeled.
+func Category(sku string) (scenario.Category, bool) {
+	words := map[string]bool{}
+	for _, w := range strings.Fields(strings.ToLower(sku)) {
+		// Match plural SKU names too, e.g. Entity Reads.
+		words[w] = true
+		words[strings.TrimSuffix(w, "s")] = true
+	}
+
+	// TTL deletes are estimated as deletes.
+	if words["small"] {
+		return "", false
+	}
+
+	switch {
+	case words["restore"]:
+		return scenario.CategoryRestore, true
+	case words["pitr"], words["recovery"]:
+		return scenario.CategoryPITR, true
+	case words["backup"]:
+		return scenario.CategoryBackup, true
+	case words["read"]:
+		return scenario.CategoryRead, true
+	case words["write"]:
+		return scenario.CategoryWrite, true
+	case words["delete"]:
+		return scenario.CategoryDelete, true
+	case words["storage"]:
+		return scenario.CategoryStorage, true
+	case words["egress"], words["ingress"], words["network"]:
+		return scenario.CategoryNetwork, true
+	}
+
+	return "", false
+}
+
+// Filter selects the rows of Firestore, and of one project or database when
+// set.
+type Filter struct {
+	Project  string
+	Database string
+}
+
+// Match reports whether r is selected.
+func (f Filter) Match(r Row) bool {
+	if r.Service != Service {
+		return false
+	}
+
+	if f.Project != "" && r.Project != f.Project {
+		return false
+	}
+
+	if f.Database != "" {
+		return r.database() == f.Database
+	}
+
+	return true
+}
+
+// Return the database ID of r from its labels or resource name.
+func (r Row) database() string {
+	for k, v := range r.Labels {
+		if k == "database_id" || strings.HasSuffix(k, "/database_id") {
+			return v
+		}
+	}
+
+	const prefix = "/databases/"
+	if i := strings.Index(r.Resource, prefix); i >= 0 {
+		return strings.SplitN(r.Resource[i+len(prefix):], "/", 2)[0]
+	}
+
+	return ""
+}
+
+// Reconciliation is the billed cost of every category by month.
+type Reconciliation struct {
+	// Billed is the cost by month.
+	Billed map[string]scenario.Estimate
+
+	// Unmodeled is the cost of every SKU without a category.
+	Unmodeled map[string]firestore.Money
+
+	// Currencies are the billing currencies of the rows.
+	Currencies []string
+}
+
+// Months returns the billed months in order.
+func (r *Reconciliation) Months() []string {
+	var months []string
+	for m := range r.Billed {
+		months = append(months, m)
+	}
+	sort.Strings(months)
+
+	return months
+}
+
+// Reconcile sums the cost of the rows that f matches by month and category.
+func Reconcile(rows []Row, f Filter) *Reconciliation {
+	rec := &Reconciliation{
+		Billed:    map[string]scenario.Estimate{},
+		Unmodeled: map[string]firestore.Money{},
+	}
+
+	currencies := map[string]bool{}
+	for _, r := range rows {
+		if !f.Match(r) {
+			continue
+		}
+
+		if r.Currency != "" && !currencies[r.Currency] {
+			currencies[r.Currency] = true
+			rec.Currencies = append(rec.Currencies, r.Currency)
+		}
+
+		c, ok := Category(r.SKU)
+		if !ok {
+			rec.Unmodeled[r.SKU] += r.Cost
+			continue
+		}
+
+		if rec.Billed[r.Month] == nil {
+			rec.Billed[r.Month] = scenario.Estimate{}
+		}
+		rec.Billed[r.Month][c] += r.Cost
+	}
+	sort.Strings(rec.Currencies)
+
+	return rec
+}
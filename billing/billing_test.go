@@ -0,0 +1,175 @@
+package billing_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/royge/gostcalc/billing"
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+)
+
+const exportCSV = `service.description,sku.description,project.id,invoice.month,cost,currency,credits,system_labels
+Cloud Firestore,Cloud Firestore Entity Reads,app,202603,12.5,USD,"[{""amount"": -2.5}]",
+Cloud Firestore,Cloud Firestore Entity Writes,app,202603,30.25,USD,,
+Cloud Firestore,Cloud Firestore Storage,app,202603,4.10,USD,,
+Cloud Firestore,Cloud Firestore TTL Deletes,app,202603,1.00,USD,,
+Cloud Firestore,Cloud Firestore Small Ops,app,202603,1.00,USD,,
+Cloud Firestore,Cloud Firestore Backup Storage,app,202603,2.00,USD,,
+Cloud Firestore,Cloud Firestore Entity Reads,app,202604,7,USD,,
+Cloud Firestore,Cloud Firestore Entity Reads,other,202603,99,USD,,
+Cloud Firestore,Cloud Firestore Entity Reads,app,202603,5,USD,,"[{""key"": ""firestore.googleapis.com/database_id"", ""value"": ""logs""}]"
+Compute Engine,N1 Core,app,202603,50,USD,,
+`
+
+const exportNDJSON = `{"service": {"description": "Cloud Firestore"}, "sku": {"description": "Cloud Firestore Entity Deletes"}, "project": {"id": "app"}, "usage_start_time": "2026-03-31 23:00:00 UTC", "cost": 0.4, "currency": "USD"}
+
+{"service": {"description": "Cloud Firestore"}, "sku": {"description": "Cloud Firestore Network Egress"}, "project": {"id": "app"}, "resource": {"name": "projects/app/databases/logs"}, "invoice": {"month": "202603"}, "cost": 1.2e-1, "currency": "USD"}
+`
+
+func TestReconcile(t *testing.T) {
+	rows, err := billing.ReadCSV(strings.NewReader(exportCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	more, err := billing.ReadNDJSON(strings.NewReader(exportNDJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows = append(rows, more...)
+
+	tt := []struct {
+		name      string
+		filter    billing.Filter
+		march     scenario.Estimate
+		april     scenario.Estimate
+		unmodeled firestore.Money
+	}{
+		{
+			"all",
+			billing.Filter{},
+			scenario.Estimate{
+				scenario.CategoryRead:    10*firestore.Dollar + 99*firestore.Dollar + 5*firestore.Dollar,
+				scenario.CategoryWrite:   3025 * firestore.Cent,
+				scenario.CategoryStorage: 410 * firestore.Cent,
+				scenario.CategoryDelete:  140 * firestore.Cent,
+				scenario.CategoryNetwork: 12 * firestore.Cent,
+				scenario.CategoryBackup:  2 * firestore.Dollar,
+			},
+			scenario.Estimate{scenario.CategoryRead: 7 * firestore.Dollar},
+			firestore.Dollar,
+		},
+		{
+			"project",
+			billing.Filter{Project: "app"},
+			scenario.Estimate{
+				scenario.CategoryRead:    15 * firestore.Dollar,
+				scenario.CategoryWrite:   3025 * firestore.Cent,
+				scenario.CategoryStorage: 410 * firestore.Cent,
+				scenario.CategoryDelete:  140 * firestore.Cent,
+				scenario.CategoryNetwork: 12 * firestore.Cent,
+				scenario.CategoryBackup:  2 * firestore.Dollar,
+			},
+			scenario.Estimate{scenario.CategoryRead: 7 * firestore.Dollar},
+			firestore.Dollar,
+		},
+		{
+			"database",
+			billing.Filter{Project: "app", Database: "logs"},
+			scenario.Estimate{
+				scenario.CategoryRead:    5 * firestore.Dollar,
+				scenario.CategoryNetwork: 12 * firestore.Cent,
+			},
+			nil,
+			0,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			rec := billing.Reconcile(rows, tc.filter)
+
+			for month, want := range map[string]scenario.Estimate{"2026-03": tc.march, "2026-04": tc.april} {
+				got := rec.Billed[month]
+				for _, c := range scenario.Categories {
+					if got[c] != want[c] {
+						t.Errorf("%s %s: want %v, got %v", month, c, want[c], got[c])
+					}
+				}
+			}
+
+			if got := rec.Unmodeled["Cloud Firestore Small Ops"]; got != tc.unmodeled {
+				t.Errorf("want unmodeled %v, got %v", tc.unmodeled, got)
+			}
+		})
+	}
+}
+
+func TestCategory(t *testing.T) {
+	tt := []struct {
+		sku  string
+		want scenario.Category
+		ok   bool
+	}{
+		{"Cloud Firestore Entity Reads", scenario.CategoryRead, true},
+		{"Cloud Firestore Read Ops", scenario.CategoryRead, true},
+		{"Cloud Firestore Entity Writes", scenario.CategoryWrite, true},
+		{"Cloud Firestore Entity Deletes", scenario.CategoryDelete, true},
+		{"Cloud Firestore Storage", scenario.CategoryStorage, true},
+		{"Cloud Firestore Internet Egress", scenario.CategoryNetwork, true},
+		{"Cloud Firestore Backup Storage", scenario.CategoryBackup, true},
+		{"Cloud Firestore Backup Restore", scenario.CategoryRestore, true},
+		{"Cloud Firestore PITR Storage", scenario.CategoryPITR, true},
+		{"Cloud Firestore Small Ops", "", false},
+		{"Cloud Firestore TTL Deletes", scenario.CategoryDelete, true},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.sku, func(t *testing.T) {
+			got, ok := billing.Category(tc.sku)
+			if got != tc.want || ok != tc.ok {
+				t.Errorf("want %q %v, got %q %v", tc.want, tc.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	tt := []struct {
+		name  string
+		input string
+		read  func(string) ([]billing.Row, error)
+	}{
+		{
+			"missing column",
+			"service.description,cost\n",
+			func(s string) ([]billing.Row, error) { return billing.ReadCSV(strings.NewReader(s)) },
+		},
+		{
+			"bad cost",
+			"service.description,sku.description,cost,invoice.month\nCloud Firestore,x,free,202603\n",
+			func(s string) ([]billing.Row, error) { return billing.ReadCSV(strings.NewReader(s)) },
+		},
+		{
+			"no month",
+			`{"service": {"description": "Cloud Firestore"}, "cost": 1}`,
+			func(s string) ([]billing.Row, error) { return billing.ReadNDJSON(strings.NewReader(s)) },
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.read(tc.input); !errors.Is(err, billing.ErrFormat) {
+				t.Errorf("want ErrFormat, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/royge/gostcalc/scenario"
+	"github.com/royge/gostcalc/usage"
+	"github.com/spf13/cobra"
+)
+
+var actualDaily bool
+
+// RegisterActual register/initialize CLI command to price measured usage.
+func RegisterActual() {
+	rootCmd.AddCommand(actualCmd)
+
+	actualCmd.Flags().StringVarP(
+		&scenarioFile,
+		"scenario",
+		"s",
+		"",
+		"JSON scenario file of the estimate to compare against",
+	)
+
+	actualCmd.Flags().BoolVarP(
+		&actualDaily,
+		"daily",
+		"d",
+		false,
+		"Print the usage and costs of every day",
+	)
+}
+
+var actualCmd = &cobra.Command{
+	Use:   "actual FILE...",
+	Short: "Price measured usage from Cloud Monitoring metrics.",
+	Long: `Price measured usage from Cloud Monitoring metric exports.
+
+Files ending in .json are timeSeries.list responses, other files are CSV with
+metric, time and value columns. The priced metrics are:
+
+  ` + strings.Join([]string{
+		usage.MetricReads,
+		usage.MetricWrites,
+		usage.MetricDeletes,
+		usage.MetricStorage,
+	}, "\n  ") + `
+
+Operations are summed by Pacific Time day and priced after the daily free tier,
+and stored bytes are averaged by day and priced as a day's share of a month.
+
+With --scenario, the monthly estimate of the scenario is prorated to the
+measured days and compared against the actual costs. Network is not measured
+by these metrics and is left out.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		var samples []usage.Sample
+		for _, path := range args {
+			s, err := usage.Load(path)
+			if err != nil {
+				log.Fatalf("unable to load metrics: %v", err)
+			}
+			samples = append(samples, s...)
+		}
+
+		u := usage.Aggregate(samples)
+		if len(u.Days) == 0 {
+			log.Fatalf("no usage found in %s", strings.Join(args, ", "))
+		}
+
+		ctx := context.Background()
+
+		if actualDaily {
+			t := &table{header: []string{
+				"Date", "Reads", "Writes", "Deletes", "Storage Bytes", "Costs",
+			}}
+			for _, d := range u.Days {
+				e, err := d.Cost(ctx, p.Prices)
+				if err != nil {
+					log.Fatalf("unable to price usage: %v", err)
+				}
+				t.add(
+					d.Date.Format(usage.DateLayout),
+					fmt.Sprint(d.Reads),
+					fmt.Sprint(d.Writes),
+					fmt.Sprint(d.Deletes),
+					fmt.Sprint(d.StorageBytes),
+					e.Total().String(),
+				)
+			}
+
+			if err := t.print(os.Stdout); err != nil {
+				log.Fatalf("unable to print usage: %v", err)
+			}
+			fmt.Println()
+		}
+
+		actual, err := u.Cost(ctx, p.Prices)
+		if err != nil {
+			log.Fatalf("unable to price usage: %v", err)
+		}
+
+		// Network, backups and recovery are not measured.
+		categories := []scenario.Category{
+			scenario.CategoryWrite,
+			scenario.CategoryRead,
+			scenario.CategoryDelete,
+			scenario.CategoryStorage,
+		}
+		days := len(u.Days)
+
+		var estimate scenario.Estimate
+		if scenarioFile != "" {
+			s, err := loadScenario()
+			if err != nil {
+				log.Fatalf("unable to load scenario: %v", err)
+			}
+
+			monthly, err := s.Estimate(ctx, p.Prices)
+			if err != nil {
+				log.Fatalf("unable to estimate scenario: %v", err)
+			}
+
+			estimate = scenario.Estimate{}
+			for c, cost := range usage.Prorate(monthly, days) {
+				for _, measured := range categories {
+					if c == measured {
+						estimate[c] = cost
+					}
+				}
+			}
+		}
+
+		fmt.Printf(
+			"Actual costs of %d days, %s to %s:\n\n",
+			days,
+			u.Days[0].Date.Format(usage.DateLayout),
+			u.Days[days-1].Date.Format(usage.DateLayout),
+		)
+
+		if estimate == nil {
+			t := &table{header: []string{"Category", "Actual"}}
+			for _, c := range categories {
+				t.add(string(c), actual[c].String())
+			}
+			t.add("Total", actual.Total().String())
+
+			if err := t.print(os.Stdout); err != nil {
+				log.Fatalf("unable to print costs: %v", err)
+			}
+		} else {
+			t := &table{header: []string{"Category", "Estimate", "Actual", "Variance", "%"}}
+			for _, c := range categories {
+				t.add(
+					string(c),
+					estimate[c].String(),
+					actual[c].String(),
+					signed(actual[c]-estimate[c]),
+					percent(estimate[c], actual[c]),
+				)
+			}
+			t.add(
+				"Total",
+				estimate.Total().String(),
+				actual.Total().String(),
+				signed(actual.Total()-estimate.Total()),
+				percent(estimate.Total(), actual.Total()),
+			)
+
+			if err := t.print(os.Stdout); err != nil {
+				log.Fatalf("unable to print variance: %v", err)
+			}
+		}
+
+		if len(u.Ignored) > 0 {
+			var ignored []string
+			for m := range u.Ignored {
+				ignored = append(ignored, m)
+			}
+			sort.Strings(ignored)
+
+			fmt.Println()
+			fmt.Println("Ignored metrics:", strings.Join(ignored, ", "))
+		}
+
+		fmt.Println()
+		fmt.Println(p)
+	},
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/royge/gostcalc/scenario"
+	"github.com/spf13/cobra"
+)
+
+// RegisterAdvise register/initialize CLI command to suggest cost savings.
+func RegisterAdvise() {
+	rootCmd.AddCommand(adviseCmd)
+
+	adviseCmd.Flags().StringVarP(
+		&scenarioFile,
+		"scenario",
+		"s",
+		"",
+		"JSON scenario file to advise on",
+	)
+}
+
+var adviseCmd = &cobra.Command{
+	Use:   "advise",
+	Short: "Suggest document model changes that save costs.",
+	Long: `Suggest document model changes that save costs, ranked by monthly savings.
+
+Suggestions are shortening long field names, exempting unqueried fields from
+single-field indexing, dropping composite indexes covered by others, adding a
+TTL policy to log collections and merging documents read together.
+
+TTL deletes are billed as deletes, and merged documents store the fields and
+index entries of the documents they replace. Splitting documents is never
+suggested: reads are billed per document, so a split cannot cut them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		s, err := loadScenario()
+		if err != nil {
+			log.Fatalf("unable to load scenario: %v", err)
+		}
+
+		suggestions, err := scenario.Advise(context.Background(), p.Prices, s)
+		if err != nil {
+			log.Fatalf("unable to advise: %v", err)
+		}
+
+		if len(suggestions) == 0 {
+			fmt.Println("No savings found.")
+			fmt.Println(p)
+			return
+		}
+
+		t := &table{header: []string{"#", "Savings", "Suggestion"}, left: true}
+		for i, sg := range suggestions {
+			t.add(fmt.Sprint(i+1), sg.Savings.String(), sg.Description)
+		}
+
+		if err := t.print(os.Stdout); err != nil {
+			log.Fatalf("unable to print suggestions: %v", err)
+		}
+
+		fmt.Println()
+		fmt.Println(p)
+	},
+}
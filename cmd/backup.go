@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupRetention int64
+	backupInterval  int64
+	backupRestores  int64
+)
+
+// RegisterBackup register/initialize CLI command to calculate backup and
+// recovery costs.
+func RegisterBackup() {
+	rootCmd.AddCommand(backupCmd)
+
+	backupCmd.Flags().Int64VarP(
+		&dailyTxn,
+		"count",
+		"c",
+		10,
+		"Total number of daily transactions",
+	)
+
+	backupCmd.Flags().Int64VarP(
+		&population,
+		"population",
+		"p",
+		1000000,
+		"Total number of active users",
+	)
+
+	backupCmd.Flags().Int64VarP(
+		&documentSize,
+		"document-size",
+		"z",
+		0,
+		"Measured document size in bytes instead of the sample document",
+	)
+
+	backupCmd.Flags().Int64VarP(
+		&backupRetention,
+		"retention",
+		"r",
+		7,
+		"Number of days every backup is kept",
+	)
+
+	backupCmd.Flags().Int64VarP(
+		&backupInterval,
+		"interval",
+		"i",
+		1,
+		"Number of days between backups, 1 for daily and 7 for weekly",
+	)
+
+	backupCmd.Flags().Int64Var(
+		&backupRestores,
+		"restores",
+		0,
+		"Number of full restores every month",
+	)
+}
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Calculate firestore backup and recovery costs.",
+	Long: `Calculate firestore backup, restore and point-in-time recovery costs.
+
+Every backup is a full copy of the stored data, restores are billed per GB
+restored, and point-in-time recovery keeps a version of every written document
+for 7 days.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		storage := &firestore.MonthlyStorageCalculator{
+			D: &firestore.DailyStorageCalculator{
+				Document: scenario.DefaultDocument(),
+				Size:     documentSize,
+			},
+		}
+
+		calcs := []struct {
+			name string
+			calc interface {
+				Calculate(context.Context, *big.Int) (firestore.Money, error)
+			}
+		}{
+			{
+				"Backup",
+				&firestore.MonthlyBackupCalculator{
+					S:             storage,
+					RetentionDays: backupRetention,
+					IntervalDays:  backupInterval,
+					Price:         p.Prices.Backup,
+				},
+			},
+			{
+				"Restore",
+				&firestore.MonthlyRestoreCalculator{
+					S:        storage,
+					Restores: backupRestores,
+					Price:    p.Prices.Restore,
+				},
+			},
+			{
+				"PITR",
+				&firestore.MonthlyPITRCalculator{
+					D:     storage.D,
+					Price: p.Prices.PITR,
+				},
+			},
+		}
+
+		for _, c := range calcs {
+			cost, err := c.calc.Calculate(
+				context.Background(),
+				big.NewInt(population*dailyTxn),
+			)
+			if err != nil {
+				log.Fatalf("unable to calculate %s cost: %v", c.name, err)
+			}
+
+			fmt.Printf("Estimated %s Cost: %s %s\n", c.name, p.Rate.To, cost)
+		}
+		fmt.Println(p)
+	},
+}
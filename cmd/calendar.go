@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+	"github.com/spf13/cobra"
+)
+
+var (
+	calendarMonth string
+	profile       = scenario.DefaultProfile()
+)
+
+// RegisterCalendar register/initialize CLI command to estimate calendar
+// months.
+func RegisterCalendar() {
+	rootCmd.AddCommand(calendarCmd)
+
+	calendarCmd.Flags().StringVar(
+		&calendarMonth,
+		"month",
+		"",
+		"Calendar month of the estimate as YYYY-MM, the current Pacific Time month by default",
+	)
+
+	calendarCmd.Flags().StringVarP(
+		&scenarioFile,
+		"scenario",
+		"s",
+		"",
+		"JSON scenario file of the estimate",
+	)
+
+	calendarCmd.Flags().Float64Var(
+		&profile.Weekday,
+		"weekday",
+		1,
+		"Relative traffic of Monday to Friday",
+	)
+
+	calendarCmd.Flags().Float64Var(
+		&profile.Weekend,
+		"weekend",
+		1,
+		"Relative traffic of Saturday and Sunday",
+	)
+
+	calendarCmd.Flags().Float64Var(
+		&profile.Holiday,
+		"holiday",
+		1,
+		"Relative traffic of holidays",
+	)
+
+	calendarCmd.Flags().StringSliceVar(
+		&profile.Holidays,
+		"holidays",
+		nil,
+		"Dates of the holidays as YYYY-MM-DD",
+	)
+}
+
+var calendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "Estimate a calendar month day by day.",
+	Long: `Estimate a calendar month of 28 to 31 days day by day.
+
+The daily free quota resets at midnight Pacific Time, so every calendar day
+is priced on its own with its own free quota and the days are summed, instead
+of applying the free quota once to an averaged day of a 30-day month.
+
+Weekdays, weekend days and holidays have their relative traffic of the
+profile flags, or of the profile of the scenario when the flags are not set,
+scaled so the average day has the workload of the scenario. Storage and
+ingress are billed for the days of the month.
+
+The report compares the calendar month with the averaged 30-day estimate.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// The month of the free quota days, in Pacific Time.
+		loc := firestore.QuotaLocation()
+
+		month := time.Now().In(loc)
+		if calendarMonth != "" {
+			var err error
+			if month, err = time.ParseInLocation(scenario.MonthLayout, calendarMonth, loc); err != nil {
+				log.Fatalf("unable to parse month: %v", err)
+			}
+		}
+
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		s, err := loadScenario()
+		if err != nil {
+			log.Fatalf("unable to load scenario: %v", err)
+		}
+
+		if s.Profile == nil {
+			s.Profile = scenario.DefaultProfile()
+		}
+		flags := map[string]func(){
+			"weekday":  func() { s.Profile.Weekday = profile.Weekday },
+			"weekend":  func() { s.Profile.Weekend = profile.Weekend },
+			"holiday":  func() { s.Profile.Holiday = profile.Holiday },
+			"holidays": func() { s.Profile.Holidays = profile.Holidays },
+		}
+		for name, set := range flags {
+			if cmd.Flags().Changed(name) {
+				set()
+			}
+		}
+		if err := s.Validate(); err != nil {
+			log.Fatalf("invalid profile: %v", err)
+		}
+
+		ctx := context.Background()
+
+		averaged, err := s.Estimate(ctx, p.Prices)
+		if err != nil {
+			log.Fatalf("unable to estimate scenario: %v", err)
+		}
+
+		e, err := s.EstimateMonth(ctx, p.Prices, month)
+		if err != nil {
+			log.Fatalf("unable to estimate month: %v", err)
+		}
+
+		t := &table{header: []string{"Operation", "30 Days", month.Format(scenario.MonthLayout), "Difference"}}
+		row := func(name string, avg, cal interface{ String() string }, diff string) {
+			t.add(name, avg.String(), cal.String(), diff)
+		}
+		for _, c := range scenario.Used(averaged, e) {
+			row(string(c), averaged[c], e[c], (e[c] - averaged[c]).String())
+		}
+		row("Total", averaged.Total(), e.Total(), (e.Total() - averaged.Total()).String())
+
+		if err := t.print(os.Stdout); err != nil {
+			log.Fatalf("unable to print estimate: %v", err)
+		}
+
+		fmt.Println()
+		fmt.Println(p)
+	},
+}
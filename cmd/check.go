@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/royge/gostcalc/policy"
+	"github.com/royge/gostcalc/scenario"
+	"github.com/spf13/cobra"
+)
+
+// checkFailedExit is the exit code when a policy rule fails.
+const checkFailedExit = 3
+
+var (
+	policyFile   string
+	baselineFile string
+)
+
+// RegisterCheck register/initialize CLI command to check estimates against a
+// cost policy.
+func RegisterCheck() {
+	rootCmd.AddCommand(checkCmd)
+
+	checkCmd.Flags().StringVarP(
+		&policyFile,
+		"policy",
+		"p",
+		"",
+		"JSON policy file of the cost limits",
+	)
+	_ = checkCmd.MarkFlagRequired("policy")
+
+	checkCmd.Flags().StringVarP(
+		&scenarioFile,
+		"scenario",
+		"s",
+		"",
+		"JSON scenario file of the estimate",
+	)
+
+	checkCmd.Flags().StringVarP(
+		&baselineFile,
+		"baseline",
+		"b",
+		"",
+		"JSON scenario file of the baseline estimate, overrides the policy baseline",
+	)
+}
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check the estimate against a cost policy.",
+	Long: `Check the monthly estimate against the limits of a JSON policy file:
+
+  {
+    "max_total": "500.00",
+    "max_category": {"read": "200.00", "storage": "50.00"},
+    "max_per_user": "0.05",
+    "max_increase_percent": 10,
+    "baseline": "baseline.json"
+  }
+
+Limits that are not set are not checked. The increase is the change of the
+total over the estimate of the baseline scenario file, relative to the policy
+file. The increase rule fails when there is no baseline.
+
+Every rule is printed with its result. The command exits with status ` + fmt.Sprint(checkFailedExit) + ` when
+any rule fails, so it can gate CI pipelines.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		pol, err := policy.Load(policyFile)
+		if err != nil {
+			log.Fatalf("unable to load policy: %v", err)
+		}
+		if baselineFile != "" {
+			pol.Baseline = baselineFile
+		}
+
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		s, err := loadScenario()
+		if err != nil {
+			log.Fatalf("unable to load scenario: %v", err)
+		}
+
+		ctx := context.Background()
+
+		estimate, err := s.Estimate(ctx, p.Prices)
+		if err != nil {
+			log.Fatalf("unable to estimate scenario: %v", err)
+		}
+
+		var baseline scenario.Estimate
+		if pol.Baseline != "" {
+			b, err := scenario.Load(pol.Baseline)
+			if err != nil {
+				log.Fatalf("unable to load baseline: %v", err)
+			}
+			if b.Location == "" {
+				b.Location = location
+			}
+
+			baseline, err = b.Estimate(ctx, p.Prices)
+			if err != nil {
+				log.Fatalf("unable to estimate baseline: %v", err)
+			}
+		}
+
+		results := pol.Check(s, estimate, baseline)
+		if len(results) == 0 {
+			log.Fatalf("no rules to check in %s", policyFile)
+		}
+
+		t := &table{header: []string{"Rule", "Limit", "Actual", "Result"}}
+		for _, r := range results {
+			result := "PASS"
+			if !r.Pass {
+				result = "FAIL"
+			}
+			t.add(r.Rule, r.Limit, r.Actual, result)
+		}
+
+		if err := t.print(os.Stdout); err != nil {
+			log.Fatalf("unable to print results: %v", err)
+		}
+
+		fmt.Println()
+		fmt.Println(p)
+
+		if !policy.Passed(results) {
+			os.Exit(checkFailedExit)
+		}
+	},
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+	"github.com/spf13/cobra"
+)
+
+var compareMarkdown bool
+
+// RegisterCompare register/initialize CLI command to compare scenarios.
+func RegisterCompare() {
+	rootCmd.AddCommand(compareCmd)
+
+	compareCmd.Flags().BoolVarP(
+		&compareMarkdown,
+		"markdown",
+		"m",
+		false,
+		"Print markdown tables",
+	)
+}
+
+var compareCmd = &cobra.Command{
+	Use:   "compare BASE SCENARIO...",
+	Short: "Compare the costs of scenarios side by side.",
+	Long: `Compare the monthly costs of two or more JSON scenario files side by side.
+
+Every scenario is compared against the first one, and the difference of its
+total is attributed to each changed input.`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		var scenarios []*scenario.Scenario
+		for _, path := range args {
+			s, err := scenario.Load(path)
+			if err != nil {
+				log.Fatalf("unable to load scenario: %v", err)
+			}
+			if s.Name == "" || s.Name == scenario.Default().Name {
+				s.Name = path
+			}
+
+			scenarios = append(scenarios, s)
+		}
+
+		c, err := scenario.Compare(context.Background(), p.Prices, scenarios...)
+		if err != nil {
+			log.Fatalf("unable to compare scenarios: %v", err)
+		}
+
+		format := outputFormat
+		if compareMarkdown {
+			format = formatMarkdown
+		}
+
+		costs := costTable(c)
+		costs.format = format
+		if err := costs.print(os.Stdout); err != nil {
+			log.Fatalf("unable to print comparison: %v", err)
+		}
+
+		for i, attrs := range c.Attributions[1:] {
+			fmt.Printf("\nCost drivers of %s:\n\n", c.Scenarios[i+1].Name)
+
+			t := &table{header: []string{"Input", "Delta"}, format: format}
+			for _, a := range attrs {
+				t.add(a.Input, signed(a.Delta))
+			}
+			t.add("Total", signed(c.Estimates[i+1].Total()-c.Estimates[0].Total()))
+
+			if err := t.print(os.Stdout); err != nil {
+				log.Fatalf("unable to print comparison: %v", err)
+			}
+		}
+
+		fmt.Println()
+		fmt.Println(p)
+	},
+}
+
+// Build the side-by-side category table of a comparison.
+func costTable(c *scenario.Comparison) *table {
+	t := &table{header: []string{"Category", c.Scenarios[0].Name}}
+	for _, s := range c.Scenarios[1:] {
+		t.header = append(t.header, s.Name, "Delta", "%")
+	}
+
+	base := c.Estimates[0]
+	row := func(name string, value func(scenario.Estimate) firestore.Money) {
+		cells := []string{name, value(base).String()}
+		for _, e := range c.Estimates[1:] {
+			cells = append(
+				cells,
+				value(e).String(),
+				signed(value(e)-value(base)),
+				percent(value(base), value(e)),
+			)
+		}
+		t.add(cells...)
+	}
+
+	for _, cat := range scenario.Used(c.Estimates...) {
+		cat := cat
+		row(string(cat), func(e scenario.Estimate) firestore.Money { return e[cat] })
+	}
+	row("Total", scenario.Estimate.Total)
+
+	return t
+}
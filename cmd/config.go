@@ -0,0 +1,369 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/royge/gostcalc/currency"
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	// ConfigFile is the name of the config file in the working directory.
+	ConfigFile = ".gostcalc.json"
+
+	// EnvPrefix is the prefix of environment variables of settings.
+	EnvPrefix = "GOSTCALC_"
+)
+
+// Sources of setting values.
+const (
+	sourceDefault = "default"
+	sourceEnv     = "env"
+	sourceFlag    = "flag"
+)
+
+// Output formats of report tables.
+const (
+	formatText     = "text"
+	formatMarkdown = "markdown"
+	formatCSV      = "csv"
+)
+
+var (
+	location     string
+	outputFormat string
+	priceFlags   []string
+)
+
+// setting is a configurable default of an input.
+type setting struct {
+	// key in config files, also the flag name.
+	key string
+
+	// value resolved from the highest precedence source.
+	value string
+
+	// source of value.
+	source string
+}
+
+// Env returns the environment variable of the setting.
+func (s *setting) env() string {
+	r := strings.NewReplacer(".", "_", "-", "_")
+
+	return EnvPrefix + strings.ToUpper(r.Replace(s.key))
+}
+
+// settings are resolved by applyConfig before every command.
+var settings []*setting
+
+// Keys and built-in defaults of every setting.
+var settingDefaults = []struct{ key, value string }{
+	{"population", strconv.FormatInt(scenario.Default().Population, 10)},
+	{"count", strconv.FormatInt(scenario.Default().Count, 10)},
+	{"writes", number(scenario.Default().Writes)},
+	{"reads", number(scenario.Default().Reads)},
+	{"deletes", number(scenario.Default().Deletes)},
+	{"scenario", ""},
+	{"location", ""},
+	{"currency", currency.Base},
+	{"rates", ""},
+	{"prices", ""},
+	{"price.write", ""},
+	{"price.read", ""},
+	{"price.delete", ""},
+	{"price.storage", ""},
+	{"price.ingress", ""},
+	{"price.backup", ""},
+	{"price.restore", ""},
+	{"price.pitr", ""},
+	{"price.export_storage", ""},
+	{"format", formatText},
+}
+
+// RegisterConfig register/initialize CLI flags and commands of settings
+// shared by every command.
+func RegisterConfig() {
+	rootCmd.PersistentPreRunE = applyConfig
+
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configShowCmd)
+
+	rootCmd.PersistentFlags().StringVar(
+		&location,
+		"location",
+		"",
+		"Firestore location of the database, descriptive only (prices do not depend on it)",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&outputFormat,
+		"format",
+		formatText,
+		"Output format of tables: text, markdown or csv",
+	)
+
+	rootCmd.PersistentFlags().StringArrayVar(
+		&priceFlags,
+		"price",
+		nil,
+		"Unit price override as sku=amount in the report currency (repeatable)",
+	)
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage settings.",
+	Long: `Manage settings.
+
+Settings are read from, by increasing precedence:
+
+  built-in defaults
+  gostcalc/config.json in the user config directory: $XDG_CONFIG_HOME or
+    ~/.config on Linux, ~/Library/Application Support on macOS and
+    %AppData% on Windows
+  ` + ConfigFile + ` in the working directory
+  ` + EnvPrefix + `* environment variables, e.g. ` + EnvPrefix + `POPULATION
+  command flags
+
+The location setting is descriptive only, it is recorded in scenarios and
+does not change any price.`,
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the resolved settings and their source.",
+	Long: `Print the resolved settings and where each one came from.
+
+The location is descriptive only, prices do not depend on it.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		t := &table{header: []string{"Key", "Value", "Source"}, left: true}
+		for _, s := range settings {
+			t.add(s.key, s.value, s.source)
+		}
+
+		if err := t.print(cmd.OutOrStdout()); err != nil {
+			return err
+		}
+
+		_, err := fmt.Fprintln(cmd.OutOrStdout(), "\nThe location is descriptive only, prices do not depend on it.")
+
+		return err
+	},
+}
+
+// Resolve every setting and use it as the default of the flags of cmd that
+// were not given.
+func applyConfig(cmd *cobra.Command, _ []string) error {
+	resolved, err := resolveSettings(cmd.Flags(), configFiles(), os.LookupEnv)
+	if err != nil {
+		return err
+	}
+	settings = resolved
+
+	for _, s := range settings {
+		f := cmd.Flags().Lookup(s.key)
+		if f == nil || f.Changed || s.source == sourceDefault {
+			continue
+		}
+
+		if err := f.Value.Set(s.value); err != nil {
+			return fmt.Errorf("invalid %s %q from %s: %w", s.key, s.value, s.source, err)
+		}
+	}
+
+	switch outputFormat {
+	case formatText, formatMarkdown, formatCSV:
+	default:
+		return fmt.Errorf("unknown format %q, want text, markdown or csv", outputFormat)
+	}
+
+	return nil
+}
+
+// Return the config files from the lowest precedence.
+func configFiles() []string {
+	var files []string
+
+	if dir, err := os.UserConfigDir(); err == nil {
+		files = append(files, filepath.Join(dir, "gostcalc", "config.json"))
+	}
+
+	return append(files, ConfigFile)
+}
+
+// Resolve every setting from its default, files, environment and the changed
+// flags, by increasing precedence.
+func resolveSettings(flags *pflag.FlagSet, files []string, lookupEnv func(string) (string, bool)) ([]*setting, error) {
+	var resolved []*setting
+	byKey := map[string]*setting{}
+	for _, d := range settingDefaults {
+		s := &setting{key: d.key, value: d.value, source: sourceDefault}
+		resolved = append(resolved, s)
+		byKey[d.key] = s
+	}
+
+	for _, path := range files {
+		values, err := readConfig(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, k := range sortedKeys(values) {
+			s, ok := byKey[k]
+			if !ok {
+				return nil, fmt.Errorf("%s: unknown setting %q", path, k)
+			}
+
+			s.value, s.source = values[k], path
+		}
+	}
+
+	for _, s := range resolved {
+		if v, ok := lookupEnv(s.env()); ok {
+			s.value, s.source = v, sourceEnv+" "+s.env()
+		}
+	}
+
+	flags.Visit(func(f *pflag.Flag) {
+		if s, ok := byKey[f.Name]; ok {
+			s.value, s.source = f.Value.String(), sourceFlag+" --"+f.Name
+		}
+	})
+
+	// Price flags are repeated sku=amount values.
+	for _, p := range priceFlags {
+		parts := strings.SplitN(p, "=", 2)
+		s, ok := byKey["price."+parts[0]]
+		if len(parts) != 2 || !ok {
+			return nil, fmt.Errorf("invalid --price %q, want sku=amount", p)
+		}
+
+		s.value, s.source = parts[1], sourceFlag+" --price"
+	}
+
+	return resolved, nil
+}
+
+// Read a JSON config file into flat keys. Nested objects are joined with
+// dots, e.g. {"price": {"write": 0.09}} is price.write.
+func readConfig(path string) (map[string]string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, fmt.Errorf("unable to decode %s: %w", path, err)
+	}
+
+	values := map[string]string{}
+
+	var flatten func(prefix string, m map[string]interface{})
+	flatten = func(prefix string, m map[string]interface{}) {
+		for k, v := range m {
+			switch v := v.(type) {
+			case map[string]interface{}:
+				flatten(prefix+k+".", v)
+			case float64:
+				values[prefix+k] = strconv.FormatFloat(v, 'f', -1, 64)
+			default:
+				values[prefix+k] = fmt.Sprint(v)
+			}
+		}
+	}
+	flatten("", raw)
+
+	return values, nil
+}
+
+// Return the resolved value of a setting.
+func settingValue(key string) string {
+	for _, s := range settings {
+		if s.key == key {
+			return s.value
+		}
+	}
+
+	return ""
+}
+
+// Apply the configured workload defaults to s. Settings that are not resolved
+// keep the values of s.
+func applyDefaults(s *scenario.Scenario) error {
+	var err error
+	parse := func(key string, def float64) float64 {
+		if settingValue(key) == "" {
+			return def
+		}
+
+		v, perr := strconv.ParseFloat(settingValue(key), 64)
+		if perr != nil && err == nil {
+			err = fmt.Errorf("invalid %s: %w", key, perr)
+		}
+		return v
+	}
+
+	s.Population = int64(parse("population", float64(s.Population)))
+	s.Count = int64(parse("count", float64(s.Count)))
+	s.Writes = parse("writes", s.Writes)
+	s.Reads = parse("reads", s.Reads)
+	s.Deletes = parse("deletes", s.Deletes)
+
+	return err
+}
+
+// Apply the configured unit price overrides to p.
+func applyPrices(p firestore.PriceList) (firestore.PriceList, error) {
+	prices := map[string]*firestore.Money{
+		currency.SKUWrite:   &p.Write,
+		currency.SKURead:    &p.Read,
+		currency.SKUDelete:  &p.Delete,
+		currency.SKUStorage: &p.Storage,
+		currency.SKUIngress: &p.Ingress,
+		currency.SKUBackup:  &p.Backup,
+		currency.SKURestore: &p.Restore,
+		currency.SKUPITR:    &p.PITR,
+
+		currency.SKUExportStorage: &p.ExportStorage,
+	}
+
+	for sku, price := range prices {
+		v := settingValue("price." + sku)
+		if v == "" {
+			continue
+		}
+
+		m, err := firestore.ParsePrice(v)
+		if err != nil {
+			return p, fmt.Errorf("invalid price.%s: %w", sku, err)
+		}
+		*price = m
+	}
+
+	return p, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestResolveSettings(t *testing.T) {
+	dir := t.TempDir()
+
+	user := filepath.Join(dir, "user.json")
+	if err := ioutil.WriteFile(user, []byte(`{"population": 5000, "count": 2, "location": "eur3"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	project := filepath.Join(dir, "project.json")
+	if err := ioutil.WriteFile(project, []byte(`{"count": 3, "price": {"write": 0.09}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	env := map[string]string{
+		"GOSTCALC_COUNT":       "4",
+		"GOSTCALC_PRICE_WRITE": "0.10",
+		"GOSTCALC_READS":       "2.5",
+	}
+	lookupEnv := func(k string) (string, bool) {
+		v, ok := env[k]
+		return v, ok
+	}
+
+	var count int64
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Int64Var(&count, "count", 10, "")
+	if err := flags.Parse([]string{"--count", "6"}); err != nil {
+		t.Fatal(err)
+	}
+
+	files := []string{user, project, filepath.Join(dir, "missing.json")}
+	got, err := resolveSettings(flags, files, lookupEnv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tc := []struct {
+		key    string
+		value  string
+		source string
+	}{
+		{"population", "5000", user},
+		{"count", "6", "flag --count"},
+		{"reads", "2.5", "env GOSTCALC_READS"},
+		{"deletes", "1", "default"},
+		{"location", "eur3", user},
+		{"price.write", "0.10", "env GOSTCALC_PRICE_WRITE"},
+		{"format", "text", "default"},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.key, func(t *testing.T) {
+			for _, s := range got {
+				if s.key != tt.key {
+					continue
+				}
+
+				if s.value != tt.value || s.source != tt.source {
+					t.Errorf("want %q from %q, got %q from %q", tt.value, tt.source, s.value, s.source)
+				}
+				return
+			}
+
+			t.Errorf("missing setting %q", tt.key)
+		})
+	}
+}
+
+func TestResolveSettingsUnknownKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := ioutil.WriteFile(path, []byte(`{"populaton": 5000}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	noEnv := func(string) (string, bool) { return "", false }
+
+	if _, err := resolveSettings(flags, []string{path}, noEnv); err == nil {
+		t.Error("want error, got nil")
+	}
+}
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/royge/gostcalc/currency"
+	"github.com/royge/gostcalc/firestore"
+)
+
+var (
+	currencyCode string
+	ratesFile    string
+	pricesFile   string
+)
+
+// RegisterCurrency register/initialize CLI flags to report costs in a local
+// currency.
+func RegisterCurrency() {
+	rootCmd.PersistentFlags().StringVar(
+		&currencyCode,
+		"currency",
+		currency.Base,
+		"Currency of the estimates",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&ratesFile,
+		"rates",
+		"",
+		"Exchange-rate CSV file with date,from,to,rate columns",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&pricesFile,
+		"prices",
+		"",
+		"Local price override CSV file with currency,sku,price columns",
+	)
+}
+
+// pricing is the price list and exchange rate used for a report.
+type pricing struct {
+	Prices firestore.PriceList
+	Rate   currency.Rate
+
+	// Overridden is set when published local prices replace list prices.
+	Overridden bool
+}
+
+// String describes the currency and rate of the report.
+func (p *pricing) String() string {
+	var sources []string
+	switch {
+	case p.Rate.Rate != nil:
+		sources = append(sources, p.Rate.String())
+	case p.Rate.To == currency.Base:
+		sources = append(sources, "list prices")
+	}
+	if p.Overridden {
+		sources = append(sources, "price overrides")
+	}
+
+	return fmt.Sprintf("Currency: %s (%s)", p.Rate.To, strings.Join(sources, ", "))
+}
+
+// Load the price list in the selected currency.
+func loadPricing() (*pricing, error) {
+	code := strings.ToUpper(currencyCode)
+
+	p := &pricing{
+		Prices: firestore.DefaultPrices,
+		Rate:   currency.Rate{From: currency.Base, To: code},
+	}
+
+	var o currency.Overrides
+	if pricesFile != "" {
+		f, err := os.Open(pricesFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open price overrides: %w", err)
+		}
+		defer f.Close()
+
+		o, err = currency.ReadOverrides(f)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Published prices for every SKU need no exchange rate.
+	if missing := o.Missing(code); code != currency.Base && len(missing) > 0 {
+		if ratesFile == "" {
+			return nil, fmt.Errorf(
+				"--rates is required for currency %s without prices for %s",
+				code,
+				strings.Join(missing, ", "),
+			)
+		}
+
+		rate, err := loadRate(code)
+		if err != nil {
+			return nil, err
+		}
+
+		p.Rate = rate
+		p.Prices = currency.ConvertPrices(p.Prices, rate)
+	}
+
+	if len(o[code]) > 0 {
+		p.Prices = o.Apply(code, p.Prices)
+		p.Overridden = true
+	}
+
+	prices, err := applyPrices(p.Prices)
+	if err != nil {
+		return nil, err
+	}
+	p.Prices = prices
+
+	return p, nil
+}
+
+// Load the exchange rate from the list price currency to code.
+func loadRate(code string) (currency.Rate, error) {
+	f, err := os.Open(ratesFile)
+	if err != nil {
+		return currency.Rate{}, fmt.Errorf("unable to open rates: %w", err)
+	}
+	defer f.Close()
+
+	table, err := currency.ReadTable(f)
+	if err != nil {
+		return currency.Rate{}, err
+	}
+
+	return table.Lookup(currency.Base, code, time.Now())
+}
+
+// Convert price overrides in the list price currency to the currency of p,
+// loading the exchange rate when p has none.
+func (p *pricing) convert(o *firestore.PriceOverrides) (*firestore.PriceOverrides, error) {
+	if o == nil || p.Rate.To == currency.Base {
+		return o, nil
+	}
+
+	if p.Rate.Rate == nil {
+		if ratesFile == "" {
+			return nil, fmt.Errorf("--rates is required to convert price overrides to %s", p.Rate.To)
+		}
+
+		rate, err := loadRate(p.Rate.To)
+		if err != nil {
+			return nil, err
+		}
+		p.Rate = rate
+	}
+
+	return currency.ConvertOverrides(o, p.Rate), nil
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/royge/gostcalc/export"
+	"github.com/spf13/cobra"
+)
+
+var exportGroup string
+
+// RegisterExport register/initialize CLI command to measure the documents of
+// a Firestore managed export.
+func RegisterExport() {
+	firestoreCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVarP(
+		&exportGroup,
+		"group",
+		"g",
+		"",
+		"Only report this collection group",
+	)
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export-sizes DIR",
+	Short: "Measure document sizes in a managed export.",
+	Long: `Measure document sizes in a local copy of a "gcloud firestore export".
+
+Every output-N file under DIR is decoded offline, and the documents are sized
+like Document.Size() without indexes, which exports do not include. The report
+has the document count, total, mean and percentile sizes in bytes and the mean
+automatic index entries of every collection group, and how many documents have
+each top-level field and its value types.
+
+The mean size is a measured document_size for scenario files.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		s, err := export.Scan(context.Background(), args[0])
+		if err != nil {
+			log.Fatalf("unable to read export: %v", err)
+		}
+
+		if err := printStats(s, exportGroup); err != nil {
+			log.Fatalf("unable to print sizes: %v", err)
+		}
+	},
+}
+
+// Print the sizes and fields of every collection group of s, or only of
+// group when set.
+func printStats(s *export.Stats, group string) error {
+	names := s.Names()
+	if group != "" {
+		if _, ok := s.Groups[group]; !ok {
+			return fmt.Errorf("collection group %q not found", group)
+		}
+		names = []string{group}
+	}
+
+	if len(names) == 0 {
+		fmt.Println("No documents found.")
+		return nil
+	}
+
+	sizes := &table{header: []string{
+		"Collection Group", "Documents", "Total", "Mean", "P50", "P90", "P99", "Max", "Index Entries",
+	}}
+	for _, n := range names {
+		g := s.Groups[n]
+		sizes.add(
+			n,
+			fmt.Sprint(g.Count()),
+			fmt.Sprint(g.Total),
+			fmt.Sprint(g.Mean()),
+			fmt.Sprint(g.Percentile(50)),
+			fmt.Sprint(g.Percentile(90)),
+			fmt.Sprint(g.Percentile(99)),
+			fmt.Sprint(g.Percentile(100)),
+			fmt.Sprintf("%.1f", float64(g.IndexEntries)/float64(g.Count())),
+		)
+	}
+
+	if err := sizes.print(os.Stdout); err != nil {
+		return err
+	}
+
+	for _, n := range names {
+		g := s.Groups[n]
+
+		fmt.Printf("\nFields of %s:\n\n", n)
+
+		fields := make([]string, 0, len(g.Fields))
+		for f := range g.Fields {
+			fields = append(fields, f)
+		}
+		sort.Slice(fields, func(i, j int) bool {
+			if g.Fields[fields[i]] != g.Fields[fields[j]] {
+				return g.Fields[fields[i]] > g.Fields[fields[j]]
+			}
+			return fields[i] < fields[j]
+		})
+
+		t := &table{header: []string{"Field", "Documents", "%", "Types"}, left: true}
+		for _, f := range fields {
+			var types []string
+			for typ := range g.Types[f] {
+				types = append(types, typ)
+			}
+			sort.Strings(types)
+
+			t.add(
+				f,
+				fmt.Sprint(g.Fields[f]),
+				fmt.Sprintf("%.1f%%", float64(g.Fields[f])/float64(g.Count())*100),
+				strings.Join(types, ", "),
+			)
+		}
+
+		if err := t.print(os.Stdout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
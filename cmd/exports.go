@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportDocuments   int64
+	exportSchedule    string
+	exportRetention   int64
+	exportImports     int64
+	exportDailyReads  int64
+	exportDailyWrites int64
+)
+
+// RegisterExports register/initialize CLI command to calculate managed export
+// and import costs.
+func RegisterExports() {
+	rootCmd.AddCommand(exportsCmd)
+
+	exportsCmd.Flags().Int64VarP(
+		&exportDocuments,
+		"documents",
+		"n",
+		1000000,
+		"Total number of exported documents",
+	)
+
+	exportsCmd.Flags().Int64VarP(
+		&documentSize,
+		"document-size",
+		"z",
+		0,
+		"Measured document size in bytes instead of the sample document",
+	)
+
+	exportsCmd.Flags().StringVar(
+		&exportSchedule,
+		"schedule",
+		"daily",
+		"Export schedule, daily, weekly or a number of days between exports",
+	)
+
+	exportsCmd.Flags().Int64VarP(
+		&exportRetention,
+		"retention",
+		"r",
+		7,
+		"Number of days every export is kept in Cloud Storage",
+	)
+
+	exportsCmd.Flags().Int64Var(
+		&exportImports,
+		"imports",
+		0,
+		"Number of full imports every month",
+	)
+
+	exportsCmd.Flags().Int64Var(
+		&exportDailyReads,
+		"reads",
+		0,
+		"Other daily document reads, which use the free reads first",
+	)
+
+	exportsCmd.Flags().Int64Var(
+		&exportDailyWrites,
+		"writes",
+		0,
+		"Other daily document writes, which use the free writes first",
+	)
+}
+
+var exportsCmd = &cobra.Command{
+	Use:   "exports",
+	Short: "Calculate managed export and import costs.",
+	Long: `Calculate the costs of "gcloud firestore export" and import schedules.
+
+Every export is billed as one document read per exported document, and every
+import as one document write per imported document. The kept export
+generations are billed as Cloud Storage without index entries.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		interval, err := parseSchedule(exportSchedule)
+		if err != nil {
+			log.Fatalf("unable to parse schedule: %v", err)
+		}
+
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		ctx := context.Background()
+		count := big.NewInt(exportDocuments)
+
+		exports := &firestore.MonthlyExportCalculator{
+			D:            &firestore.DailyReadCalculator{Price: p.Prices.Read},
+			IntervalDays: interval,
+			DailyReads:   big.NewInt(exportDailyReads),
+		}
+		imports := &firestore.MonthlyImportCalculator{
+			D:           &firestore.DailyWriteCalculator{Price: p.Prices.Write},
+			Imports:     exportImports,
+			DailyWrites: big.NewInt(exportDailyWrites),
+		}
+		storage := &firestore.MonthlyExportStorageCalculator{
+			Document:      scenario.DefaultDocument(),
+			Size:          documentSize,
+			RetentionDays: exportRetention,
+			IntervalDays:  interval,
+			Price:         p.Prices.ExportStorage,
+		}
+
+		reads, err := exports.Calculate(ctx, count)
+		if err != nil {
+			log.Fatalf("unable to calculate export cost: %v", err)
+		}
+
+		writes, err := imports.Calculate(ctx, count)
+		if err != nil {
+			log.Fatalf("unable to calculate import cost: %v", err)
+		}
+
+		stored, err := storage.Calculate(ctx, count)
+		if err != nil {
+			log.Fatalf("unable to calculate export storage cost: %v", err)
+		}
+
+		fmt.Printf("Exports: %d, Billed Reads: %s\n", exports.Exports(), exports.Reads(count))
+		fmt.Printf("Estimated Export Cost: %s %s\n", p.Rate.To, reads)
+		fmt.Printf("Imports: %d, Billed Writes: %s\n", imports.Imports, imports.Writes(count))
+		fmt.Printf("Estimated Import Cost: %s %s\n", p.Rate.To, writes)
+		fmt.Printf("Kept Exports: %d, Bytes: %s\n", storage.Generations(), storage.Bytes(count))
+		fmt.Printf("Estimated Export Storage Cost: %s %s\n", p.Rate.To, stored)
+		fmt.Println(p)
+	},
+}
+
+// Parse a daily or weekly schedule, or a number of days between runs.
+func parseSchedule(s string) (int64, error) {
+	switch strings.ToLower(s) {
+	case "daily":
+		return 1, nil
+	case "weekly":
+		return 7, nil
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("unknown schedule %q", s)
+	}
+
+	return n, nil
+}
@@ -15,8 +15,10 @@ import (
 )
 
 var (
-	dailyTxn   int64
-	population int64
+	dailyTxn     int64
+	population   int64
+	documentSize int64
+	idStrategy   string
 )
 
 // RegisterFirestore register/initialize CLI command to calculate firestore
@@ -60,6 +62,21 @@ func RegisterFirestore() {
 		"Total number of active users",
 	)
 
+	storageCmd.Flags().Int64VarP(
+		&documentSize,
+		"document-size",
+		"z",
+		0,
+		"Measured document size in bytes instead of the sample document",
+	)
+
+	storageCmd.Flags().StringVar(
+		&idStrategy,
+		"id-strategy",
+		"",
+		"ID strategy of the sample document: auto, uuid, ulid, numeric or a length",
+	)
+
 	writeCmd.Flags().Int64VarP(
 		&dailyTxn,
 		"count",
@@ -114,6 +131,11 @@ var networkCmd = &cobra.Command{
 	Short: "Calculate network ingress costs.",
 	Long:  "Calculate network ingress costs.",
 	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
 		data := map[string]interface{}{
 			"id":          uuid.New(),
 			"profile_id":  uuid.New(),
@@ -130,7 +152,7 @@ var networkCmd = &cobra.Command{
 			D: &firestore.DailyNetworkingCalculator{
 				Document: doc,
 			},
-			Price: firestore.IngressPricePerGB,
+			Price: p.Prices.Ingress,
 		}
 
 		cost, err := calc.Calculate(
@@ -142,7 +164,8 @@ var networkCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		fmt.Println("Estimated Networking Cost: $", cost)
+		fmt.Println("Estimated Networking Cost:", p.Rate.To, cost)
+		fmt.Println(p)
 	},
 }
 
@@ -151,49 +174,65 @@ var storageCmd = &cobra.Command{
 	Short: "Calculate firestore storage costs.",
 	Long:  "Calculate firestore storage costs.",
 	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		doc := &firestore.Document{
+			ID: uuid.New().String(),
+			Collection: fmt.Sprintf(
+				"prod-qr/%s/qr-records",
+				uuid.New().String(),
+			),
+			Data: map[string]interface{}{
+				"merchant_id":    uuid.New().String(),
+				"merchant_qr_id": uuid.New().String(),
+				"profile_qr_id":  uuid.New().String(),
+				"date_created":   time.Now(),
+				"type":           1,
+				// "is_auto_scanout": false,
+				"is_auto_scanout": map[string]interface{}{
+					"Bool":  false,
+					"Valid": false,
+				},
+			},
+			SingleFieldIndexes: []map[string]interface{}{
+				{
+					"date_created": time.Now(),
+				},
+			},
+			CompositeIndexes: []map[string]interface{}{
+				{
+					"merchant_id":  uuid.New().String(),
+					"date_created": time.Now(),
+				},
+				{
+					"merchant_id":  uuid.New().String(),
+					"type":         1,
+					"date_created": time.Now(),
+				},
+				{
+					"type":         1,
+					"date_created": time.Now(),
+				},
+			},
+		}
+
+		if idStrategy != "" {
+			id, err := firestore.ParseIDStrategy(idStrategy)
+			if err != nil {
+				log.Fatalf("unable to parse ID strategy: %v", err)
+			}
+			doc = doc.WithIDs(id)
+		}
+
 		calc := &firestore.MonthlyStorageCalculator{
 			D: &firestore.DailyStorageCalculator{
-				Document: &firestore.Document{
-					ID: uuid.New().String(),
-					Collection: fmt.Sprintf(
-						"prod-qr/%s/qr-records",
-						uuid.New().String(),
-					),
-					Data: map[string]interface{}{
-						"merchant_id":    uuid.New().String(),
-						"merchant_qr_id": uuid.New().String(),
-						"profile_qr_id":  uuid.New().String(),
-						"date_created":   time.Now(),
-						"type":           1,
-						// "is_auto_scanout": false,
-						"is_auto_scanout": map[string]interface{}{
-							"Bool":  false,
-							"Valid": false,
-						},
-					},
-					SingleFieldIndexes: []map[string]interface{}{
-						{
-							"date_created": time.Now(),
-						},
-					},
-					CompositeIndexes: []map[string]interface{}{
-						{
-							"merchant_id":  uuid.New().String(),
-							"date_created": time.Now(),
-						},
-						{
-							"merchant_id":  uuid.New().String(),
-							"type":         1,
-							"date_created": time.Now(),
-						},
-						{
-							"type":         1,
-							"date_created": time.Now(),
-						},
-					},
-				},
+				Document: doc,
+				Size:     documentSize,
 			},
-			Price: firestore.PricePerGB,
+			Price: p.Prices.Storage,
 		}
 
 		cost, err := calc.Calculate(
@@ -205,7 +244,8 @@ var storageCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		fmt.Println("Estimated Storage Cost: $", cost)
+		fmt.Println("Estimated Storage Cost:", p.Rate.To, cost)
+		fmt.Println(p)
 	},
 }
 
@@ -214,8 +254,15 @@ var writeCmd = &cobra.Command{
 	Short: "Calculate firestore write costs.",
 	Long:  "Calculate firestore write costs.",
 	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
 		calc := &firestore.MonthlyWriteCalculator{
-			D: &firestore.DailyWriteCalculator{},
+			D: &firestore.DailyWriteCalculator{
+				Price: p.Prices.Write,
+			},
 		}
 
 		dailyWrites := big.NewInt(population * dailyTxn)
@@ -227,7 +274,8 @@ var writeCmd = &cobra.Command{
 			log.Fatalf("unable to calculate daily writes: %v", err)
 		}
 
-		fmt.Println("Estimated Writes Cost: $", cost)
+		fmt.Println("Estimated Writes Cost:", p.Rate.To, cost)
+		fmt.Println(p)
 	},
 }
 
@@ -236,8 +284,15 @@ var deleteCmd = &cobra.Command{
 	Short: "Calculate firestore delete costs.",
 	Long:  "Calculate firestore delete costs.",
 	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
 		calc := &firestore.MonthlyDeleteCalculator{
-			D: &firestore.DailyDeleteCalculator{},
+			D: &firestore.DailyDeleteCalculator{
+				Price: p.Prices.Delete,
+			},
 		}
 
 		dailyDeletes := big.NewInt(population * dailyTxn)
@@ -249,7 +304,8 @@ var deleteCmd = &cobra.Command{
 			log.Fatalf("unable to calculate daily deletes: %v", err)
 		}
 
-		fmt.Println("Estimated Deletes Cost: $", cost)
+		fmt.Println("Estimated Deletes Cost:", p.Rate.To, cost)
+		fmt.Println(p)
 	},
 }
 
@@ -258,8 +314,15 @@ var readCmd = &cobra.Command{
 	Short: "Calculate firestore read costs.",
 	Long:  "Calculate firestore read costs.",
 	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
 		calc := &firestore.MonthlyReadCalculator{
-			D: &firestore.DailyReadCalculator{},
+			D: &firestore.DailyReadCalculator{
+				Price: p.Prices.Read,
+			},
 		}
 
 		dailyReads := big.NewInt(population * dailyTxn)
@@ -271,6 +334,7 @@ var readCmd = &cobra.Command{
 			log.Fatalf("unable to calculate daily reads: %v", err)
 		}
 
-		fmt.Println("Estimated Reads Cost: $", cost)
+		fmt.Println("Estimated Reads Cost:", p.Rate.To, cost)
+		fmt.Println(p)
 	},
 }
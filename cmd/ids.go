@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+	"github.com/spf13/cobra"
+)
+
+var (
+	idsModelFile  string
+	idsStrategies []string
+)
+
+// RegisterIDs register/initialize CLI command to compare document ID
+// strategies.
+func RegisterIDs() {
+	rootCmd.AddCommand(idsCmd)
+
+	idsCmd.Flags().StringVarP(
+		&scenarioFile,
+		"scenario",
+		"s",
+		"",
+		"JSON scenario file of the estimate",
+	)
+
+	idsCmd.Flags().StringVarP(
+		&idsModelFile,
+		"model",
+		"m",
+		"",
+		"JSON data model file of the estimate instead of a scenario",
+	)
+
+	var names []string
+	for _, id := range firestore.IDStrategies {
+		names = append(names, string(id))
+	}
+
+	idsCmd.Flags().StringSliceVar(
+		&idsStrategies,
+		"strategies",
+		names,
+		"ID strategies to compare, named or custom lengths",
+	)
+}
+
+var idsCmd = &cobra.Command{
+	Use:   "ids",
+	Short: "Compare the storage costs of document ID strategies.",
+	Long: `Compare the monthly storage costs of document ID strategies.
+
+The strategies are auto (20 character Firestore IDs), uuid (36 characters),
+ulid (26 characters), numeric (integer IDs stored in 8 bytes) and custom
+lengths such as 12. Each strategy generates the document IDs and the parent
+document IDs of the scenario document, or of every collection of a data model.
+Placeholders of path templates with their own strategy keep it.
+
+The ID size flows through the document names and the index entries, and the
+storage cost is compared with the estimate as configured.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		var strategies []firestore.IDStrategy
+		for _, s := range idsStrategies {
+			id, err := firestore.ParseIDStrategy(s)
+			if err != nil {
+				log.Fatalf("unable to parse strategies: %v", err)
+			}
+			strategies = append(strategies, id)
+		}
+
+		ctx := context.Background()
+
+		// Estimate the storage cost with IDs of a strategy, or as configured
+		// when it is empty.
+		var storage func(id firestore.IDStrategy) (firestore.Money, error)
+
+		if idsModelFile != "" {
+			m, err := scenario.LoadModel(idsModelFile)
+			if err != nil {
+				log.Fatalf("unable to load model: %v", err)
+			}
+
+			storage = func(id firestore.IDStrategy) (firestore.Money, error) {
+				c := m
+				if id != "" {
+					c = m.WithIDs(id)
+				}
+
+				e, err := c.Estimate(ctx, p.Prices)
+				if err != nil {
+					return 0, err
+				}
+
+				return e.Total[scenario.CategoryStorage], nil
+			}
+		} else {
+			s, err := loadScenario()
+			if err != nil {
+				log.Fatalf("unable to load scenario: %v", err)
+			}
+
+			storage = func(id firestore.IDStrategy) (firestore.Money, error) {
+				c := *s
+				if id != "" {
+					c.IDStrategy = id
+				}
+
+				e, err := c.Estimate(ctx, p.Prices)
+				if err != nil {
+					return 0, err
+				}
+
+				return e[scenario.CategoryStorage], nil
+			}
+		}
+
+		current, err := storage("")
+		if err != nil {
+			log.Fatalf("unable to estimate storage: %v", err)
+		}
+
+		t := &table{header: []string{"Strategy", "ID Size", "Storage", "Delta", "%"}}
+		t.add("current", "", current.String(), "", "")
+
+		for _, id := range strategies {
+			cost, err := storage(id)
+			if err != nil {
+				log.Fatalf("unable to estimate storage: %v", err)
+			}
+
+			t.add(
+				string(id),
+				fmt.Sprint(id.Size()),
+				cost.String(),
+				signed(cost-current),
+				percent(current, cost),
+			)
+		}
+
+		if err := t.print(os.Stdout); err != nil {
+			log.Fatalf("unable to print comparison: %v", err)
+		}
+
+		fmt.Println()
+		fmt.Println(p)
+	},
+}
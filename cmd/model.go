@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/royge/gostcalc/scenario"
+	"github.com/spf13/cobra"
+)
+
+// RegisterModel register/initialize CLI command to estimate data models.
+func RegisterModel() {
+	rootCmd.AddCommand(modelCmd)
+}
+
+var modelCmd = &cobra.Command{
+	Use:   "model FILE",
+	Short: "Estimate a data model of many collections.",
+	Long: `Estimate the monthly cost of a JSON data model of many collections:
+
+  {
+    "name": "app",
+    "collections": [
+      {
+        "name": "users",
+        "document": {"id": "...", "collection": "users", "data": {...}},
+        "documents": 1000000,
+        "daily_growth": 500,
+        "writes": 20000,
+        "reads": 400000,
+        "deletes": 100
+      },
+      {
+        "path": "profiles/{profileId:36}/logs/{logId}",
+        "document": {"data": {...}},
+        "monthly_fan_out": 40,
+        "writes": 150000
+      }
+    ]
+  }
+
+Operations are daily counts, documents are stored at the start of the month
+and daily_growth documents are added every day.
+
+A path template sizes document names from its IDs, {name} for IDs of the
+id_strategy of the collection or model, automatic IDs by default, and
+{name:strategy} for auto, uuid, ulid, numeric or N character IDs. A collection
+under a parent path derives its documents from the parent collection with
+fan_out documents per parent document, and its daily growth with
+monthly_fan_out documents added per parent document every month.
+recursive_deletes documents are deleted daily with the documents of their
+subcollections, which are billed as deletes and as reads of the queries
+finding them.
+
+The usage of every collection is added up so the free tier is applied once to
+the whole database, and the cost of each category is shared between the
+collections in proportion to their usage.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		m, err := scenario.LoadModel(args[0])
+		if err != nil {
+			log.Fatalf("unable to load model: %v", err)
+		}
+
+		e, err := m.Estimate(context.Background(), p.Prices)
+		if err != nil {
+			log.Fatalf("unable to estimate model: %v", err)
+		}
+
+		categories := scenario.Used(e.Total)
+
+		t := &table{header: []string{"Collection"}}
+		for _, c := range categories {
+			t.header = append(t.header, string(c))
+		}
+		t.header = append(t.header, "Total")
+
+		row := func(name string, e scenario.Estimate) {
+			cells := []string{name}
+			for _, c := range categories {
+				cells = append(cells, e[c].String())
+			}
+			t.add(append(cells, e.Total().String())...)
+		}
+
+		for _, c := range m.Collections {
+			row(c.Name, e.Collections[c.Name])
+		}
+		row("Total", e.Total)
+
+		if err := t.print(os.Stdout); err != nil {
+			log.Fatalf("unable to print estimate: %v", err)
+		}
+
+		fmt.Println()
+		fmt.Println(p)
+	},
+}
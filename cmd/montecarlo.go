@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+	"github.com/spf13/cobra"
+)
+
+var (
+	monteCarloDists  []string
+	monteCarloTrials int
+	monteCarloSeed   int64
+)
+
+// Percentiles reported by the montecarlo command.
+var monteCarloPercentiles = []float64{10, 50, 90, 99}
+
+// RegisterMonteCarlo register/initialize CLI command to estimate costs from
+// input distributions.
+func RegisterMonteCarlo() {
+	rootCmd.AddCommand(monteCarloCmd)
+
+	monteCarloCmd.Flags().StringVarP(
+		&scenarioFile,
+		"scenario",
+		"s",
+		"",
+		"JSON scenario file of the fixed inputs",
+	)
+
+	monteCarloCmd.Flags().StringArrayVarP(
+		&monteCarloDists,
+		"dist",
+		"d",
+		nil,
+		"Input distribution as name=kind:parameters (repeatable)",
+	)
+
+	monteCarloCmd.Flags().IntVarP(
+		&monteCarloTrials,
+		"trials",
+		"n",
+		10000,
+		"Number of trials",
+	)
+
+	monteCarloCmd.Flags().Int64Var(
+		&monteCarloSeed,
+		"seed",
+		0,
+		"Random seed to reproduce results, 0 picks one from the clock",
+	)
+}
+
+var monteCarloCmd = &cobra.Command{
+	Use:   "montecarlo",
+	Short: "Estimate cost percentiles from input distributions.",
+	Long: `Estimate monthly cost percentiles from input distributions.
+
+Every trial draws the --dist inputs from their distribution and estimates the
+monthly costs. Distributions are:
+
+  uniform:min,max
+  normal:mean,stddev
+  lognormal:mu,sigma
+  triangular:min,mode,max
+
+Inputs are ` + strings.Join(scenario.InputNames(), ", ") + `.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		base, err := loadScenario()
+		if err != nil {
+			log.Fatalf("unable to load scenario: %v", err)
+		}
+
+		dists := map[string]scenario.Distribution{}
+		for _, f := range monteCarloDists {
+			parts := strings.SplitN(f, "=", 2)
+			if len(parts) != 2 {
+				log.Fatalf("invalid dist %q: want name=kind:parameters", f)
+			}
+
+			d, err := scenario.ParseDistribution(parts[1])
+			if err != nil {
+				log.Fatalf("invalid dist: %v", err)
+			}
+
+			dists[parts[0]] = d
+		}
+
+		if monteCarloSeed == 0 {
+			monteCarloSeed = time.Now().UnixNano()
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt)
+		go func() {
+			<-interrupt
+			cancel()
+		}()
+
+		sim, err := scenario.Simulate(ctx, p.Prices, base, dists, monteCarloTrials, monteCarloSeed)
+		if err != nil {
+			log.Fatalf("unable to simulate costs: %v", err)
+		}
+
+		t := &table{header: []string{"Category"}}
+		for _, q := range monteCarloPercentiles {
+			t.header = append(t.header, fmt.Sprintf("P%v", q))
+		}
+
+		row := func(name string, costs []firestore.Money) {
+			cells := []string{name}
+			for _, q := range monteCarloPercentiles {
+				cells = append(cells, scenario.Percentile(costs, q).String())
+			}
+			t.add(cells...)
+		}
+		for _, c := range scenario.Categories {
+			if len(sim.Costs[c]) > 0 {
+				row(string(c), sim.Costs[c])
+			}
+		}
+		row("Total", sim.Totals)
+
+		if err := t.print(os.Stdout); err != nil {
+			log.Fatalf("unable to print percentiles: %v", err)
+		}
+
+		fmt.Println()
+		fmt.Printf("Trials: %d, seed: %d\n", sim.Trials, monteCarloSeed)
+		fmt.Println(p)
+	},
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/royge/gostcalc/discount"
+	"github.com/spf13/cobra"
+)
+
+var (
+	termsFile      string
+	forecastMonths int
+	monthlyGrowth  float64
+)
+
+// RegisterNetCost register/initialize CLI command to forecast the net cost
+// after discounts and credits.
+func RegisterNetCost() {
+	rootCmd.AddCommand(netCostCmd)
+
+	netCostCmd.Flags().StringVarP(
+		&termsFile,
+		"terms",
+		"t",
+		"",
+		"JSON file of the discounts, commitment and credits",
+	)
+	_ = netCostCmd.MarkFlagRequired("terms")
+
+	netCostCmd.Flags().StringVarP(
+		&scenarioFile,
+		"scenario",
+		"s",
+		"",
+		"JSON scenario file of the estimate",
+	)
+
+	netCostCmd.Flags().IntVarP(
+		&forecastMonths,
+		"months",
+		"m",
+		12,
+		"Number of months of the forecast",
+	)
+
+	netCostCmd.Flags().Float64VarP(
+		&monthlyGrowth,
+		"growth",
+		"g",
+		0,
+		"Growth of the list cost every month in percent, more than -100",
+	)
+}
+
+var netCostCmd = &cobra.Command{
+	Use:   "net-cost",
+	Short: "Forecast the net cost after discounts and credits.",
+	Long: `Forecast the monthly net cost of the estimate with the terms of a JSON file:
+
+  {
+    "discounts": {"read": 10, "storage": 5},
+    "commitment": {"monthly": "100.00", "discount_percent": 20, "months": 12},
+    "credits": [
+      {"name": "startup", "amount": "2000.00", "expires_after_months": 12}
+    ]
+  }
+
+Amounts are in the report currency. Discounts are percentages of the list
+cost of each category. The committed monthly spend is always billed and
+covers the discounted usage it buys at its discount, the usage beyond it is
+billed at the discounted prices. Credits pay what is left until they run out
+or expire, the ones expiring first before the others.
+
+Every month has the list cost, the discount, the credits used, the
+out-of-pocket spend and the credit balance left.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if forecastMonths <= 0 {
+			log.Fatalf("unable to forecast %d months", forecastMonths)
+		}
+		if math.IsNaN(monthlyGrowth) || math.IsInf(monthlyGrowth, 0) || monthlyGrowth <= -100 {
+			log.Fatalf("unable to forecast a growth of %v%%, want more than -100%%", monthlyGrowth)
+		}
+
+		terms, err := discount.Load(termsFile)
+		if err != nil {
+			log.Fatalf("unable to load terms: %v", err)
+		}
+
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		s, err := loadScenario()
+		if err != nil {
+			log.Fatalf("unable to load scenario: %v", err)
+		}
+
+		e, err := s.Estimate(context.Background(), p.Prices)
+		if err != nil {
+			log.Fatalf("unable to estimate scenario: %v", err)
+		}
+
+		t := &table{header: []string{
+			"Month", "List", "Discount", "Credits Used", "Out of Pocket", "Credit Balance",
+		}}
+
+		var total discount.Month
+		for _, m := range terms.Forecast(e, forecastMonths, monthlyGrowth) {
+			t.add(
+				strconv.Itoa(m.Month),
+				m.List.String(),
+				m.Discount.String(),
+				m.Credits.String(),
+				m.OutOfPocket.String(),
+				m.Balance.String(),
+			)
+
+			total.List += m.List
+			total.Discount += m.Discount
+			total.Credits += m.Credits
+			total.OutOfPocket += m.OutOfPocket
+		}
+		t.add(
+			"Total",
+			total.List.String(),
+			total.Discount.String(),
+			total.Credits.String(),
+			total.OutOfPocket.String(),
+			"",
+		)
+
+		if err := t.print(os.Stdout); err != nil {
+			log.Fatalf("unable to print forecast: %v", err)
+		}
+
+		fmt.Println()
+		fmt.Println(p)
+	},
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/royge/gostcalc/scenario"
+	"github.com/spf13/cobra"
+)
+
+// RegisterProject register/initialize CLI command to estimate projects of
+// many databases.
+func RegisterProject() {
+	rootCmd.AddCommand(projectCmd)
+}
+
+var projectCmd = &cobra.Command{
+	Use:   "project FILE",
+	Short: "Estimate a project of many databases.",
+	Long: `Estimate the monthly cost of a JSON project of many named databases:
+
+  {
+    "name": "acme",
+    "locations": {
+      "europe-west3": {"write": "0.1", "read": "0.038", "delete": "0.004"}
+    },
+    "databases": [
+      {
+        "name": "(default)",
+        "location": "nam5",
+        "free_tier": true,
+        "scenario": {"population": 100000, "count": 5, ...}
+      },
+      {
+        "name": "analytics",
+        "location": "europe-west3",
+        "edition": "enterprise",
+        "prices": {"storage": "0.198", "ingress": "0"},
+        "scenario": {...}
+      }
+    ]
+  }
+
+Every database has its own location, edition (standard or enterprise) and
+scenario, with the inputs of scenario files. Every database is priced with
+the unit prices of the project, replaced by the prices of its location and
+then by its own prices. A price of zero makes a SKU free. The prices of
+locations and databases are list prices in USD, converted to --currency with
+the exchange rate of --rates. Enterprise databases have no list prices and
+require prices for every SKU they use from their location or their own.
+
+The free quota applies to one database of the project only: the one with
+free_tier, or else the (default) database. Every other database is billed
+for all its usage.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		project, err := scenario.LoadProject(args[0])
+		if err != nil {
+			log.Fatalf("unable to load project: %v", err)
+		}
+
+		for name, o := range project.Locations {
+			if project.Locations[name], err = p.convert(o); err != nil {
+				log.Fatalf("unable to convert prices of location %s: %v", name, err)
+			}
+		}
+		for _, db := range project.Databases {
+			if db.Prices, err = p.convert(db.Prices); err != nil {
+				log.Fatalf("unable to convert prices of database %s: %v", db.Name, err)
+			}
+		}
+
+		e, err := project.Estimate(context.Background(), p.Prices)
+		if err != nil {
+			log.Fatalf("unable to estimate project: %v", err)
+		}
+
+		categories := scenario.Used(e.Total)
+		free := project.Free()
+
+		t := &table{header: []string{"Database", "Location", "Edition", "Prices", "Free Tier"}}
+		for _, c := range categories {
+			t.header = append(t.header, string(c))
+		}
+		t.header = append(t.header, "Total")
+
+		row := func(cells []string, e scenario.Estimate) {
+			for _, c := range categories {
+				cells = append(cells, e[c].String())
+			}
+			t.add(append(cells, e.Total().String())...)
+		}
+
+		for _, db := range project.Databases {
+			tier := "no"
+			if db == free {
+				tier = "yes"
+			}
+			var sources []string
+			if project.Locations[db.Location] != nil {
+				sources = append(sources, "location")
+			}
+			if db.Prices != nil {
+				sources = append(sources, "database")
+			}
+			prices := "project"
+			if len(sources) > 0 {
+				prices = strings.Join(sources, ", ")
+			}
+			row([]string{db.Name, db.Location, string(db.Edition), prices, tier}, e.Databases[db.Name])
+		}
+		row([]string{"Project", "", "", "", ""}, e.Total)
+
+		if err := t.print(os.Stdout); err != nil {
+			log.Fatalf("unable to print estimate: %v", err)
+		}
+
+		fmt.Println()
+		fmt.Println(p)
+	},
+}
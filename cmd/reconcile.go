@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/royge/gostcalc/billing"
+	"github.com/royge/gostcalc/scenario"
+	"github.com/spf13/cobra"
+)
+
+var reconcileFilter billing.Filter
+
+// RegisterReconcile register/initialize CLI command to reconcile estimates
+// with billing exports.
+func RegisterReconcile() {
+	rootCmd.AddCommand(reconcileCmd)
+
+	reconcileCmd.Flags().StringVarP(
+		&scenarioFile,
+		"scenario",
+		"s",
+		"",
+		"JSON scenario file of the estimate",
+	)
+
+	reconcileCmd.Flags().StringVar(
+		&reconcileFilter.Project,
+		"project",
+		"",
+		"Only rows of this project ID",
+	)
+
+	reconcileCmd.Flags().StringVar(
+		&reconcileFilter.Database,
+		"database",
+		"",
+		"Only rows of this database ID",
+	)
+}
+
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile FILE...",
+	Short: "Compare billed costs with the estimate.",
+	Long: `Compare the billed costs of a Cloud Billing export with the estimate.
+
+Files ending in .json, .ndjson or .jsonl are newline-delimited JSON rows of the
+BigQuery billing export, other files are CSV dumps with flattened column names
+such as service.description, sku.description, project.id, invoice.month and
+cost. Credits are subtracted from the cost.
+
+Only Cloud Firestore rows are read, and each SKU is mapped to an estimate
+category. Every billed month is compared with the estimate of its calendar
+month. SKUs that are not modeled are reported as warnings.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		s, err := loadScenario()
+		if err != nil {
+			log.Fatalf("unable to load scenario: %v", err)
+		}
+
+		var rows []billing.Row
+		for _, path := range args {
+			r, err := billing.Load(path)
+			if err != nil {
+				log.Fatalf("unable to load billing export: %v", err)
+			}
+			rows = append(rows, r...)
+		}
+
+		rec := billing.Reconcile(rows, reconcileFilter)
+		if len(rec.Billed) == 0 && len(rec.Unmodeled) == 0 {
+			log.Fatalf("no Firestore rows found in %s", strings.Join(args, ", "))
+		}
+
+		for _, c := range rec.Currencies {
+			if c != p.Rate.To {
+				log.Printf("warning: billed in %s, estimated in %s", c, p.Rate.To)
+			}
+		}
+
+		t := &table{header: []string{
+			"Month", "Category", "Billed", "Estimate", "Difference", "%",
+		}}
+		for _, month := range rec.Months() {
+			start, err := time.Parse(billing.MonthLayout, month)
+			if err != nil {
+				log.Fatalf("invalid billing month: %v", err)
+			}
+
+			// Estimate the days of the billed month, not an average month.
+			estimate, err := s.EstimateMonth(context.Background(), p.Prices, start)
+			if err != nil {
+				log.Fatalf("unable to estimate %s: %v", month, err)
+			}
+
+			billed := rec.Billed[month]
+			for _, c := range scenario.Used(billed, estimate) {
+				t.add(
+					month,
+					string(c),
+					billed[c].String(),
+					estimate[c].String(),
+					signed(billed[c]-estimate[c]),
+					percent(estimate[c], billed[c]),
+				)
+			}
+			t.add(
+				month,
+				"Total",
+				billed.Total().String(),
+				estimate.Total().String(),
+				signed(billed.Total()-estimate.Total()),
+				percent(estimate.Total(), billed.Total()),
+			)
+		}
+
+		if err := t.print(os.Stdout); err != nil {
+			log.Fatalf("unable to print reconciliation: %v", err)
+		}
+
+		var skus []string
+		for sku := range rec.Unmodeled {
+			skus = append(skus, sku)
+		}
+		sort.Strings(skus)
+
+		for _, sku := range skus {
+			log.Printf("warning: SKU %q is not modeled, %s billed", sku, rec.Unmodeled[sku])
+		}
+
+		fmt.Println()
+		fmt.Println(p)
+	},
+}
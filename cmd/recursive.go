@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+	"github.com/spf13/cobra"
+)
+
+var (
+	recursiveCollection string
+	recursiveDeletes    int64
+)
+
+// RegisterRecursiveDelete register/initialize CLI command to calculate the
+// cost of recursive deletes of a data model.
+func RegisterRecursiveDelete() {
+	rootCmd.AddCommand(recursiveDeleteCmd)
+
+	recursiveDeleteCmd.Flags().StringVarP(
+		&recursiveCollection,
+		"collection",
+		"c",
+		"",
+		"Collection of the deleted documents",
+	)
+	_ = recursiveDeleteCmd.MarkFlagRequired("collection")
+
+	recursiveDeleteCmd.Flags().Int64VarP(
+		&recursiveDeletes,
+		"deletes",
+		"d",
+		0,
+		"Documents deleted daily, recursive_deletes of the collection by default",
+	)
+}
+
+var recursiveDeleteCmd = &cobra.Command{
+	Use:   "recursive-delete FILE",
+	Short: "Calculate the cost of recursive deletes.",
+	Long: `Calculate the cost of deleting the documents of a collection of a data
+model with every document of their subcollections, like recursiveDelete of
+the Admin SDKs.
+
+The subcollections are the collections of the model under the path of the
+collection, with fan_out documents per parent document, or else as many as
+their documents per parent document. Every subcollection document is a billed
+delete and a billed read of the query finding it, and a document without
+subcollection documents is still billed one read.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		m, err := scenario.LoadModel(args[0])
+		if err != nil {
+			log.Fatalf("unable to load model: %v", err)
+		}
+
+		rd, err := m.RecursiveDelete(recursiveCollection)
+		if err != nil {
+			log.Fatalf("unable to model recursive delete: %v", err)
+		}
+
+		daily := big.NewInt(recursiveDeletes)
+		if !cmd.Flags().Changed("deletes") {
+			for _, c := range m.Collections {
+				if c.Name == recursiveCollection {
+					daily.SetInt64(c.RecursiveDeletes)
+				}
+			}
+		}
+
+		monthly := func(n *big.Int) string {
+			return new(big.Int).Mul(n, big.NewInt(firestore.MonthNumOfDays)).String()
+		}
+
+		t := &table{header: []string{"Collection", "Deletes", "Reads"}}
+		t.add(
+			recursiveCollection,
+			monthly(daily),
+			monthly(new(big.Int).Sub(rd.Reads(daily), rd.Descendants(daily))),
+		)
+
+		cascade := rd.Cascade(daily)
+		for _, c := range m.Collections {
+			if n, ok := cascade[c.Name]; ok {
+				t.add(c.Name, monthly(n), monthly(n))
+			}
+		}
+
+		deletes, reads := rd.Deletes(daily), rd.Reads(daily)
+		t.add("Total", monthly(deletes), monthly(reads))
+
+		if err := t.print(os.Stdout); err != nil {
+			log.Fatalf("unable to print recursive delete: %v", err)
+		}
+
+		deleteCalc := &firestore.MonthlyDeleteCalculator{
+			D: &firestore.DailyDeleteCalculator{Price: p.Prices.Delete},
+		}
+		deleteCost, err := deleteCalc.Calculate(context.Background(), deletes)
+		if err != nil {
+			log.Fatalf("unable to calculate delete cost: %v", err)
+		}
+
+		readCalc := &firestore.MonthlyReadCalculator{
+			D: &firestore.DailyReadCalculator{Price: p.Prices.Read},
+		}
+		readCost, err := readCalc.Calculate(context.Background(), reads)
+		if err != nil {
+			log.Fatalf("unable to calculate read cost: %v", err)
+		}
+
+		fmt.Println()
+		fmt.Printf("Estimated Delete Cost: %s %s\n", p.Rate.To, deleteCost)
+		fmt.Printf("Estimated Read Cost: %s %s\n", p.Rate.To, readCost)
+		fmt.Println(p)
+	},
+}
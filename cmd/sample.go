@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/royge/gostcalc/emulator"
+	"github.com/royge/gostcalc/export"
+	"github.com/spf13/cobra"
+)
+
+var (
+	emulatorHost  string
+	sampleProject string
+	sampleDB      string
+	sampleLimit   int
+	sampleGroup   string
+)
+
+// RegisterSample register/initialize CLI command to measure the documents of
+// a Firestore emulator.
+func RegisterSample() {
+	firestoreCmd.AddCommand(sampleCmd)
+
+	sampleCmd.Flags().StringVar(
+		&emulatorHost,
+		"emulator-host",
+		os.Getenv("FIRESTORE_EMULATOR_HOST"),
+		"Emulator host and port, defaults to $FIRESTORE_EMULATOR_HOST",
+	)
+
+	sampleCmd.Flags().StringVar(
+		&sampleProject,
+		"project",
+		os.Getenv("GCLOUD_PROJECT"),
+		"Project ID of the emulator data, defaults to $GCLOUD_PROJECT",
+	)
+
+	sampleCmd.Flags().StringVar(
+		&sampleDB,
+		"database",
+		emulator.DefaultDatabase,
+		"Database ID",
+	)
+
+	sampleCmd.Flags().IntVarP(
+		&sampleLimit,
+		"limit",
+		"n",
+		1000,
+		"Maximum documents read per collection, 0 for all",
+	)
+
+	sampleCmd.Flags().StringVarP(
+		&sampleGroup,
+		"group",
+		"g",
+		"",
+		"Only report this collection group",
+	)
+}
+
+var sampleCmd = &cobra.Command{
+	Use:   "sample",
+	Short: "Measure document sizes in a Firestore emulator.",
+	Long: `Measure document sizes in a running Firestore emulator.
+
+Every collection and subcollection is listed through the emulator REST API, up
+to --limit documents per collection. The report is the same as export-sizes:
+document count, total, mean and percentile sizes in bytes and the mean
+automatic index entries of every collection group, and the presence and value
+types of every top-level field.
+
+Pass the mean size to "gostcalc storage --document-size", or use it as the
+document_size of a scenario file.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if emulatorHost == "" || sampleProject == "" {
+			log.Fatalf("--emulator-host and --project are required")
+		}
+
+		c := &emulator.Client{
+			Host:     emulatorHost,
+			Project:  sampleProject,
+			Database: sampleDB,
+			Limit:    sampleLimit,
+		}
+
+		s := &export.Stats{}
+		err := c.Walk(context.Background(), func(e *export.Entity) error {
+			s.Add(e)
+			return nil
+		})
+		if err != nil {
+			log.Fatalf("unable to sample emulator: %v", err)
+		}
+
+		if err := printStats(s, sampleGroup); err != nil {
+			log.Fatalf("unable to print sizes: %v", err)
+		}
+	},
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"github.com/royge/gostcalc/scenario"
+)
+
+// scenarioFile is the JSON scenario file of the commands that take one.
+var scenarioFile string
+
+// Load the selected scenario, or the default one with the configured workload
+// when none is selected.
+func loadScenario() (*scenario.Scenario, error) {
+	s := scenario.Default()
+	if scenarioFile == "" {
+		if err := applyDefaults(s); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if s, err = scenario.Load(scenarioFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.Location == "" {
+		s.Location = location
+	}
+
+	return s, s.Validate()
+}
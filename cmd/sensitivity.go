@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/royge/gostcalc/scenario"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sensitivityRanges []string
+	sensitivityGridX  string
+	sensitivityGridY  string
+	sensitivityCSV    bool
+)
+
+// RegisterSensitivity register/initialize CLI command to analyze the
+// sensitivity of costs to their inputs.
+func RegisterSensitivity() {
+	rootCmd.AddCommand(sensitivityCmd)
+
+	sensitivityCmd.Flags().StringVarP(
+		&scenarioFile,
+		"scenario",
+		"s",
+		"",
+		"JSON scenario file of the base inputs",
+	)
+
+	sensitivityCmd.Flags().StringArrayVarP(
+		&sensitivityRanges,
+		"range",
+		"r",
+		nil,
+		"Input range as name=low,base,high (repeatable)",
+	)
+
+	sensitivityCmd.Flags().StringVarP(
+		&sensitivityGridX,
+		"grid-x",
+		"x",
+		"",
+		"Grid columns as name=value,value,...",
+	)
+
+	sensitivityCmd.Flags().StringVarP(
+		&sensitivityGridY,
+		"grid-y",
+		"y",
+		"",
+		"Grid rows as name=value,value,...",
+	)
+
+	sensitivityCmd.Flags().BoolVar(
+		&sensitivityCSV,
+		"csv",
+		false,
+		"Print CSV tables",
+	)
+}
+
+var sensitivityCmd = &cobra.Command{
+	Use:   "sensitivity",
+	Short: "Rank the inputs that move the total cost most.",
+	Long: `Rank the inputs that move the total monthly cost most.
+
+Every --range input is varied from its low to its high value while all other
+inputs keep their base values, and the results are ranked in a tornado table.
+Without --range, every input is varied by 50% around the scenario value.
+
+--grid-x and --grid-y print the total cost for every pair of values of two
+inputs. Inputs are ` + strings.Join(scenario.InputNames(), ", ") + `.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		base, err := loadScenario()
+		if err != nil {
+			log.Fatalf("unable to load scenario: %v", err)
+		}
+
+		ranges, err := parseRanges(base, sensitivityRanges)
+		if err != nil {
+			log.Fatalf("invalid range: %v", err)
+		}
+
+		ctx := context.Background()
+
+		t, err := scenario.Sensitivity(ctx, p.Prices, base, ranges)
+		if err != nil {
+			log.Fatalf("unable to analyze sensitivity: %v", err)
+		}
+
+		format := outputFormat
+		if sensitivityCSV {
+			format = formatCSV
+		}
+
+		tornado := &table{
+			header: []string{
+				"Input", "Low", "Base", "High", "Low Total", "High Total", "Swing",
+			},
+			format: format,
+		}
+		for _, s := range t.Swings {
+			tornado.add(
+				s.Input,
+				number(s.Low),
+				number(s.Base),
+				number(s.High),
+				s.LowTotal.String(),
+				s.HighTotal.String(),
+				s.Width().String(),
+			)
+		}
+
+		if err := tornado.print(os.Stdout); err != nil {
+			log.Fatalf("unable to print tornado: %v", err)
+		}
+
+		if sensitivityGridX != "" || sensitivityGridY != "" {
+			x, xs, err := parseValues(sensitivityGridX)
+			if err != nil {
+				log.Fatalf("invalid grid-x: %v", err)
+			}
+
+			y, ys, err := parseValues(sensitivityGridY)
+			if err != nil {
+				log.Fatalf("invalid grid-y: %v", err)
+			}
+
+			grid, err := scenario.Grid(ctx, p.Prices, t.Base, x, xs, y, ys)
+			if err != nil {
+				log.Fatalf("unable to calculate grid: %v", err)
+			}
+
+			g := &table{header: []string{y + `\` + x}, format: format}
+			for _, xv := range xs {
+				g.header = append(g.header, number(xv))
+			}
+			for i, yv := range ys {
+				row := []string{number(yv)}
+				for _, cost := range grid[i] {
+					row = append(row, cost.String())
+				}
+				g.add(row...)
+			}
+
+			fmt.Println()
+			if err := g.print(os.Stdout); err != nil {
+				log.Fatalf("unable to print grid: %v", err)
+			}
+		}
+
+		if format != formatCSV {
+			fmt.Println()
+			fmt.Println(p)
+		}
+	},
+}
+
+// Parse name=low,base,high ranges, or vary every input by 50% around its
+// value in base when there are none.
+func parseRanges(base *scenario.Scenario, flags []string) ([]scenario.Range, error) {
+	var ranges []scenario.Range
+
+	if len(flags) == 0 {
+		for _, name := range scenario.InputNames() {
+			// A set document_size already includes the indexes.
+			if name == scenario.InputIndexes && base.DocumentSize != 0 {
+				continue
+			}
+
+			in, _ := scenario.LookupInput(name)
+			v := in.Get(base)
+
+			r := scenario.Range{Input: name, Low: v * 0.5, Base: v, High: v * 1.5}
+			if in.Integer {
+				r.Low, r.High = math.Round(r.Low), math.Round(r.High)
+			}
+
+			ranges = append(ranges, r)
+		}
+
+		return ranges, nil
+	}
+
+	for _, f := range flags {
+		name, values, err := parseValues(f)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(values) != 3 {
+			return nil, fmt.Errorf("%q: want low,base,high values", f)
+		}
+
+		ranges = append(ranges, scenario.Range{
+			Input: name,
+			Low:   values[0],
+			Base:  values[1],
+			High:  values[2],
+		})
+	}
+
+	return ranges, nil
+}
+
+// Parse name=value,value,... flags.
+func parseValues(f string) (string, []float64, error) {
+	parts := strings.SplitN(f, "=", 2)
+	if len(parts) != 2 {
+		return "", nil, fmt.Errorf("%q: want name=value,value,...", f)
+	}
+
+	if _, err := scenario.LookupInput(parts[0]); err != nil {
+		return "", nil, err
+	}
+
+	var values []float64
+	for _, s := range strings.Split(parts[1], ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("%q: %w", f, err)
+		}
+
+		values = append(values, v)
+	}
+
+	return parts[0], values, nil
+}
+
+// Format an input value.
+func number(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
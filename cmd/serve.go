@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/royge/gostcalc/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr    string
+	serveTimeout time.Duration
+)
+
+// RegisterServe register/initialize CLI command to serve the estimate API.
+func RegisterServe() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVarP(
+		&serveAddr,
+		"addr",
+		"a",
+		"localhost:8080",
+		"Address to listen on",
+	)
+
+	serveCmd.Flags().DurationVarP(
+		&serveTimeout,
+		"timeout",
+		"t",
+		api.DefaultTimeout,
+		"Time limit of each request",
+	)
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve estimates over an HTTP JSON API.",
+	Long: `Serve estimates over an HTTP JSON API.
+
+  POST /v1/estimate       itemized monthly estimate of a scenario
+  GET  /v1/prices         price book
+  POST /v1/document/size  stored size of a document
+  GET  /openapi.json      OpenAPI description`,
+	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		s := &api.Server{Prices: p.Prices, Rate: p.Rate, Timeout: serveTimeout}
+
+		listen(serveAddr, s.Handler())
+	},
+}
+
+// Serve handler on addr until interrupted.
+func listen(addr string, handler http.Handler) {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	done := make(chan struct{})
+	go func() {
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt)
+		<-interrupt
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Printf("unable to shutdown server: %v", err)
+		}
+		close(done)
+	}()
+
+	log.Printf("Listening on http://%s", addr)
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		log.Fatalf("unable to serve: %v", err)
+	}
+
+	<-done
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+	"github.com/spf13/cobra"
+)
+
+var (
+	solveBudget string
+	solveInput  string
+)
+
+// RegisterSolve register/initialize CLI command to solve the largest input
+// value within a budget.
+func RegisterSolve() {
+	rootCmd.AddCommand(solveCmd)
+
+	solveCmd.Flags().StringVarP(
+		&scenarioFile,
+		"scenario",
+		"s",
+		"",
+		"JSON scenario file of the fixed inputs",
+	)
+
+	solveCmd.Flags().StringVarP(
+		&solveBudget,
+		"budget",
+		"b",
+		"",
+		"Monthly budget in the report currency",
+	)
+
+	solveCmd.Flags().StringVarP(
+		&solveInput,
+		"input",
+		"i",
+		scenario.InputPopulation,
+		"Input to solve: population, count, document_size or indexes",
+	)
+
+	_ = solveCmd.MarkFlagRequired("budget")
+}
+
+var solveCmd = &cobra.Command{
+	Use:   "solve",
+	Short: "Find the largest input value within a monthly budget.",
+	Long: `Find the largest value of one input that keeps the total monthly cost
+within a budget, for example how many users $500 a month supports. Every
+other input keeps its scenario value.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		base, err := loadScenario()
+		if err != nil {
+			log.Fatalf("unable to load scenario: %v", err)
+		}
+
+		budget, err := firestore.ParseMoney(solveBudget)
+		if err != nil {
+			log.Fatalf("invalid budget: %v", err)
+		}
+
+		sol, err := scenario.Solve(context.Background(), p.Prices, base, solveInput, budget)
+		if err != nil {
+			log.Fatalf("unable to solve %s: %v", solveInput, err)
+		}
+
+		fmt.Printf(
+			"Maximum %s within %s %v a month: %d\n\n",
+			sol.Input,
+			p.Rate.To,
+			budget,
+			sol.Value,
+		)
+
+		t := &table{header: []string{"Category", "Costs"}}
+		for _, c := range scenario.Used(sol.Estimate) {
+			t.add(string(c), sol.Estimate[c].String())
+		}
+		t.add("Total", sol.Estimate.Total().String())
+
+		if err := t.print(os.Stdout); err != nil {
+			log.Fatalf("unable to print solution: %v", err)
+		}
+
+		fmt.Println()
+		fmt.Println("Limiting category:", sol.Limiting)
+		fmt.Println(p)
+	},
+}
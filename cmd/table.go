@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+// table is a report table printed as aligned text, markdown or CSV.
+type table struct {
+	header []string
+	rows   [][]string
+
+	// left aligns text cells to the left instead of the right.
+	left bool
+
+	// format overrides the configured output format when set.
+	format string
+}
+
+func (t *table) add(row ...string) {
+	t.rows = append(t.rows, row)
+}
+
+// Print the table in the selected output format.
+func (t *table) print(w io.Writer) error {
+	format := t.format
+	if format == "" {
+		format = outputFormat
+	}
+
+	switch format {
+	case formatMarkdown:
+		return t.markdown(w)
+	case formatCSV:
+		return t.csv(w)
+	}
+
+	return t.text(w)
+}
+
+// Print the table as aligned text.
+func (t *table) text(w io.Writer) error {
+	var flags uint = tabwriter.AlignRight
+	if t.left {
+		flags = 0
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', flags)
+
+	for _, row := range append([][]string{t.header}, t.rows...) {
+		line := strings.Join(row, "\t")
+		if !t.left {
+			// Terminate the last cell so it is aligned too.
+			line += "\t"
+		}
+
+		fmt.Fprintln(tw, line)
+	}
+
+	return tw.Flush()
+}
+
+// Print the table as a markdown table.
+func (t *table) markdown(w io.Writer) error {
+	line := func(cells []string) string {
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+
+	sep := make([]string, len(t.header))
+	for i := range sep {
+		sep[i] = "---:"
+		if i == 0 || t.left {
+			sep[i] = "---"
+		}
+	}
+
+	lines := []string{line(t.header), line(sep)}
+	for _, row := range t.rows {
+		lines = append(lines, line(row))
+	}
+
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+
+	return err
+}
+
+// Print the table as CSV.
+func (t *table) csv(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(t.header); err != nil {
+		return err
+	}
+
+	if err := cw.WriteAll(t.rows); err != nil {
+		return err
+	}
+
+	return cw.Error()
+}
+
+// Format the change from base to v as a signed percentage.
+func percent(base, v firestore.Money) string {
+	if base == 0 {
+		if v == 0 {
+			return "+0.0%"
+		}
+		return "n/a"
+	}
+
+	return fmt.Sprintf("%+.1f%%", float64(v-base)/float64(base)*100)
+}
+
+// Format a signed amount.
+func signed(m firestore.Money) string {
+	if m >= 0 {
+		return "+" + m.String()
+	}
+
+	return m.String()
+}
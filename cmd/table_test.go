@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTable_Format(t *testing.T) {
+	tb := &table{header: []string{"Category", "Cost"}, format: formatCSV}
+	tb.add("Write", "538.92")
+
+	var out bytes.Buffer
+	if err := tb.print(&out); err != nil {
+		t.Fatalf("unable to print table: %v", err)
+	}
+
+	if want := "Category,Cost\nWrite,538.92\n"; want != out.String() {
+		t.Errorf("want CSV table %q, got %q", want, out.String())
+	}
+
+	if outputFormat == formatCSV {
+		t.Error("want the configured output format unchanged")
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/royge/gostcalc/api"
+	"github.com/royge/gostcalc/ui"
+	"github.com/spf13/cobra"
+)
+
+var uiAddr string
+
+// RegisterUI register/initialize CLI command to serve the browser estimate
+// builder.
+func RegisterUI() {
+	rootCmd.AddCommand(uiCmd)
+
+	uiCmd.Flags().StringVarP(
+		&uiAddr,
+		"addr",
+		"a",
+		"localhost:8090",
+		"Address to listen on",
+	)
+}
+
+var uiCmd = &cobra.Command{
+	Use:   "ui",
+	Short: "Build estimates in the browser.",
+	Long: `Serve a page on localhost to build estimates in the browser.
+
+The page updates the cost table as the workload, document fields and indexes
+are edited, and downloads the scenario file to share it.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		s := &api.Server{Prices: p.Prices, Rate: p.Rate}
+
+		listen(uiAddr, ui.Handler(s))
+	},
+}
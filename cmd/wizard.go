@@ -0,0 +1,343 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+	"github.com/spf13/cobra"
+)
+
+// Field types the wizard asks about.
+var wizardTypes = []string{"string", "integer", "float", "boolean", "timestamp"}
+
+// RegisterWizard register/initialize CLI command to build a scenario
+// interactively.
+func RegisterWizard() {
+	rootCmd.AddCommand(firestoreCmd)
+	firestoreCmd.AddCommand(wizardCmd)
+}
+
+var firestoreCmd = &cobra.Command{
+	Use:   "firestore",
+	Short: "Firestore estimate tools.",
+	Long:  "Firestore estimate tools.",
+}
+
+var wizardCmd = &cobra.Command{
+	Use:   "wizard",
+	Short: "Describe a workload and document step by step.",
+	Long: `Describe a workload and document step by step, see its size and monthly
+estimate, and save the answers as a reusable scenario file.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPricing()
+		if err != nil {
+			log.Fatalf("unable to load prices: %v", err)
+		}
+
+		w := &wizard{in: bufio.NewReader(cmd.InOrStdin()), out: cmd.OutOrStdout()}
+		if err := w.run(context.Background(), p); err != nil {
+			log.Fatalf("wizard failed: %v", err)
+		}
+	},
+}
+
+// wizard asks questions on out and reads the answers from in.
+type wizard struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func (w *wizard) run(ctx context.Context, p *pricing) error {
+	fmt.Fprintln(w.out, "Press enter to accept the [default] answer.")
+	fmt.Fprintln(w.out)
+
+	s := scenario.Default()
+	if err := applyDefaults(s); err != nil {
+		return err
+	}
+
+	var err error
+	if s.Name, err = w.ask("Scenario name", "wizard"); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w.out, "\nUsers and activity")
+	if s.Population, err = w.int("Active users", s.Population); err != nil {
+		return err
+	}
+	if s.Count, err = w.int("Daily transactions per user", s.Count); err != nil {
+		return err
+	}
+	if s.Writes, err = w.float("Document writes per transaction", s.Writes); err != nil {
+		return err
+	}
+	if s.Reads, err = w.float("Document reads per transaction", s.Reads); err != nil {
+		return err
+	}
+	if s.Deletes, err = w.float("Document deletes per transaction", s.Deletes); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w.out, "\nDocument")
+	if s.Document, err = w.document(); err != nil {
+		return err
+	}
+
+	loc := location
+	if loc == "" {
+		loc = "nam5"
+	}
+	if s.Location, err = w.ask("Location", loc); err != nil {
+		return err
+	}
+
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	e, err := s.Estimate(ctx, p.Prices)
+	if err != nil {
+		return err
+	}
+
+	b := s.Document.Breakdown()
+	fmt.Fprintln(w.out, "\nDocument size")
+	size := &table{header: []string{"Part", "Bytes"}}
+	size.add("Name", fmt.Sprint(b.Name))
+	size.add("Data", fmt.Sprint(b.Data))
+	size.add("Single-field indexes", fmt.Sprint(b.SingleFieldIndexes))
+	size.add("Composite indexes", fmt.Sprint(b.CompositeIndexes))
+	size.add("Total", fmt.Sprint(b.Total))
+	if err := size.text(w.out); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w.out, "\nMonthly estimate")
+	costs := &table{header: []string{"Category", "Costs"}}
+	for _, c := range scenario.Used(e) {
+		costs.add(string(c), e[c].String())
+	}
+	costs.add("Total", e.Total().String())
+	if err := costs.text(w.out); err != nil {
+		return err
+	}
+	fmt.Fprintln(w.out, p)
+
+	path, err := w.ask("\nSave scenario to file (empty to skip)", "")
+	if err != nil || path == "" {
+		return err
+	}
+
+	if err := scenario.Save(path, s); err != nil {
+		return err
+	}
+	fmt.Fprintln(w.out, "Saved", path)
+
+	return nil
+}
+
+// Ask about the document path, fields and indexes.
+func (w *wizard) document() (*firestore.Document, error) {
+	collection, err := w.ask("Collection path, e.g. profiles/{id}/logs", "qr-records")
+	if err != nil {
+		return nil, err
+	}
+
+	idLen, err := w.int("Document ID length", 20)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replace {placeholders} with IDs of the same length.
+	parts := strings.Split(collection, "/")
+	for i, part := range parts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			parts[i] = strings.Repeat("x", int(idLen))
+		}
+	}
+
+	doc := &firestore.Document{
+		ID:         strings.Repeat("x", int(idLen)),
+		Collection: strings.Join(parts, "/"),
+		Data:       map[string]interface{}{},
+	}
+
+	fmt.Fprintln(w.out, "\nFields")
+	for {
+		name, err := w.ask("Field name (empty to finish)", "")
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			break
+		}
+
+		typ, err := w.choose("Type", wizardTypes)
+		if err != nil {
+			return nil, err
+		}
+
+		var v interface{}
+		switch typ {
+		case "string":
+			n, err := w.int("Typical length", 20)
+			if err != nil {
+				return nil, err
+			}
+			v = strings.Repeat("x", int(n))
+		case "integer":
+			v = 0
+		case "float":
+			v = 0.0
+		case "boolean":
+			v = false
+		case "timestamp":
+			v = time.Time{}
+		}
+		doc.Data[name] = v
+
+		indexed, err := w.yes("Index this field for queries", true)
+		if err != nil {
+			return nil, err
+		}
+		if indexed {
+			doc.SingleFieldIndexes = append(
+				doc.SingleFieldIndexes,
+				map[string]interface{}{name: v},
+			)
+		}
+	}
+
+	fmt.Fprintln(w.out, "\nComposite indexes")
+	for {
+		line, err := w.ask("Composite index fields, comma separated (empty to finish)", "")
+		if err != nil {
+			return nil, err
+		}
+		if line == "" {
+			break
+		}
+
+		idx := map[string]interface{}{}
+		for _, f := range strings.Split(line, ",") {
+			f = strings.TrimSpace(f)
+			v, ok := doc.Data[f]
+			if !ok {
+				fmt.Fprintf(w.out, "Unknown field %q, skipped.\n", f)
+				continue
+			}
+			idx[f] = v
+		}
+
+		if len(idx) > 0 {
+			doc.CompositeIndexes = append(doc.CompositeIndexes, idx)
+		}
+	}
+
+	return doc, nil
+}
+
+// Ask a question and return the answer, or def when it is empty.
+func (w *wizard) ask(question, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(w.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(w.out, "%s: ", question)
+	}
+
+	line, err := w.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		if err == io.EOF {
+			return "", io.ErrUnexpectedEOF
+		}
+		return "", err
+	}
+
+	if line = strings.TrimSpace(line); line == "" {
+		return def, nil
+	}
+
+	return line, nil
+}
+
+// Ask until the answer is a whole number that is not negative.
+func (w *wizard) int(question string, def int64) (int64, error) {
+	for {
+		a, err := w.ask(question, strconv.FormatInt(def, 10))
+		if err != nil {
+			return 0, err
+		}
+
+		v, err := strconv.ParseInt(strings.Replace(a, ",", "", -1), 10, 64)
+		if err == nil && v >= 0 {
+			return v, nil
+		}
+		fmt.Fprintln(w.out, "Please enter a whole number.")
+	}
+}
+
+// Ask until the answer is a number that is not negative.
+func (w *wizard) float(question string, def float64) (float64, error) {
+	for {
+		a, err := w.ask(question, number(def))
+		if err != nil {
+			return 0, err
+		}
+
+		v, err := strconv.ParseFloat(a, 64)
+		if err == nil && v >= 0 {
+			return v, nil
+		}
+		fmt.Fprintln(w.out, "Please enter a number.")
+	}
+}
+
+// Ask until the answer is one of choices, the first one is the default.
+func (w *wizard) choose(question string, choices []string) (string, error) {
+	for {
+		a, err := w.ask(fmt.Sprintf("%s (%s)", question, strings.Join(choices, ", ")), choices[0])
+		if err != nil {
+			return "", err
+		}
+
+		for _, c := range choices {
+			if strings.EqualFold(a, c) {
+				return c, nil
+			}
+		}
+		fmt.Fprintf(w.out, "Please choose one of %s.\n", strings.Join(choices, ", "))
+	}
+}
+
+// Ask a yes or no question.
+func (w *wizard) yes(question string, def bool) (bool, error) {
+	d := "y/N"
+	if def {
+		d = "Y/n"
+	}
+
+	for {
+		a, err := w.ask(question+" ("+d+")", "")
+		if err != nil {
+			return false, err
+		}
+
+		switch strings.ToLower(a) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(w.out, "Please answer yes or no.")
+	}
+}
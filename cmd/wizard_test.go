@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+)
+
+func TestWizard(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.json")
+
+	answers := []string{
+		"logs",               // scenario name
+		"5000",               // active users
+		"",                   // daily transactions
+		"2",                  // writes
+		"many",               // reads, invalid
+		"3",                  // reads
+		"0",                  // deletes
+		"profiles/{id}/logs", // collection
+		"",                   // id length
+		"message",            // field
+		"",                   // type string
+		"40",                 // length
+		"n",                  // not indexed
+		"created",            // field
+		"timestamp",          // type
+		"",                   // indexed
+		"",                   // end of fields
+		"created, message",   // composite index
+		"",                   // end of composite indexes
+		"eur3",               // location
+		path,                 // save
+	}
+
+	var out bytes.Buffer
+	w := &wizard{
+		in:  bufio.NewReader(strings.NewReader(strings.Join(answers, "\n") + "\n")),
+		out: &out,
+	}
+
+	p := &pricing{Prices: firestore.DefaultPrices}
+	if err := w.run(context.Background(), p); err != nil {
+		t.Fatalf("wizard failed: %v\n%s", err, out.String())
+	}
+
+	s, err := scenario.Load(path)
+	if err != nil {
+		t.Fatalf("unable to load saved scenario: %v", err)
+	}
+
+	if s.Population != 5000 || s.Count != 10 || s.Reads != 3 || s.Deletes != 0 {
+		t.Errorf("want workload answers saved, got %+v", s.Workload)
+	}
+
+	if want := "profiles/xxxxxxxxxxxxxxxxxxxx/logs"; s.Document.Collection != want {
+		t.Errorf("want collection %v, got %v", want, s.Document.Collection)
+	}
+
+	if len(s.Document.SingleFieldIndexes) != 1 || len(s.Document.CompositeIndexes) != 1 {
+		t.Errorf("want 1 single-field and 1 composite index, got %+v", s.Document)
+	}
+
+	if s.Location != "eur3" {
+		t.Errorf("want location eur3, got %v", s.Location)
+	}
+
+	if !strings.Contains(out.String(), "Please enter a number.") {
+		t.Error("want invalid answers asked again")
+	}
+}
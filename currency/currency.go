@@ -0,0 +1,169 @@
+// Package currency converts estimates into local currencies using an offline
+// exchange-rate table.
+package currency
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+const (
+	// Base is the currency of the Firestore list prices.
+	Base = "USD"
+
+	// DateLayout is the layout of dates in rate files.
+	DateLayout = "2006-01-02"
+)
+
+// ErrNoRate is returned when a table has no rate for a currency pair.
+var ErrNoRate = errors.New("no exchange rate")
+
+// Rate is the exchange rate from one currency to another on a date.
+type Rate struct {
+	// Date the rate is effective from.
+	Date time.Time
+
+	// From is the source currency code.
+	From string
+
+	// To is the target currency code.
+	To string
+
+	// Rate is the amount of To currency for one unit of From currency.
+	Rate *big.Rat
+}
+
+// String formats r for reports.
+func (r Rate) String() string {
+	return fmt.Sprintf(
+		"1 %s = %s %s as of %s",
+		r.From,
+		r.Rate.FloatString(6),
+		r.To,
+		r.Date.Format(DateLayout),
+	)
+}
+
+// Table is a list of exchange rates.
+type Table []Rate
+
+// ReadTable reads a CSV exchange-rate table with "date,from,to,rate" columns.
+// A header row is skipped when present.
+func ReadTable(r io.Reader) (Table, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 4
+	cr.TrimLeadingSpace = true
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read rates: %w", err)
+	}
+
+	var t Table
+	for i, rec := range records {
+		if i == 0 && strings.EqualFold(rec[0], "date") {
+			continue
+		}
+
+		date, err := time.Parse(DateLayout, rec[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date: %w", i+1, err)
+		}
+
+		rate, ok := new(big.Rat).SetString(rec[3])
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("line %d: invalid rate %q", i+1, rec[3])
+		}
+
+		t = append(t, Rate{
+			Date: date,
+			From: strings.ToUpper(rec[1]),
+			To:   strings.ToUpper(rec[2]),
+			Rate: rate,
+		})
+	}
+
+	return t, nil
+}
+
+// Lookup returns the latest rate from one currency to another effective on or
+// before date. Inverse rates are used when only the opposite pair is listed.
+func (t Table) Lookup(from, to string, date time.Time) (Rate, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+
+	if from == to {
+		return Rate{Date: date, From: from, To: to, Rate: big.NewRat(1, 1)}, nil
+	}
+
+	var found *Rate
+	for _, r := range t {
+		r := r
+
+		if r.Date.After(date) {
+			continue
+		}
+
+		switch {
+		case r.From == from && r.To == to:
+		case r.From == to && r.To == from:
+			r = Rate{
+				Date: r.Date,
+				From: from,
+				To:   to,
+				Rate: new(big.Rat).Inv(r.Rate),
+			}
+		default:
+			continue
+		}
+
+		if found == nil || r.Date.After(found.Date) {
+			found = &r
+		}
+	}
+
+	if found == nil {
+		return Rate{}, fmt.Errorf("%w from %s to %s", ErrNoRate, from, to)
+	}
+
+	return *found, nil
+}
+
+// Convert returns m converted with r, rounded to the micro-unit.
+func Convert(m firestore.Money, r Rate) firestore.Money {
+	v := new(big.Rat).SetInt64(int64(m))
+	v.Mul(v, r.Rate)
+	v.Quo(v, new(big.Rat).SetInt64(int64(firestore.Dollar)))
+
+	return firestore.RatMoney(v)
+}
+
+// ConvertPrices returns every price in p converted with r.
+func ConvertPrices(p firestore.PriceList, r Rate) firestore.PriceList {
+	return firestore.PriceList{
+		Write:   Convert(p.Write, r),
+		Read:    Convert(p.Read, r),
+		Delete:  Convert(p.Delete, r),
+		Storage: Convert(p.Storage, r),
+		Ingress: Convert(p.Ingress, r),
+		Backup:  Convert(p.Backup, r),
+		Restore: Convert(p.Restore, r),
+		PITR:    Convert(p.PITR, r),
+
+		ExportStorage: Convert(p.ExportStorage, r),
+	}
+}
+
+// ConvertOverrides returns every price set in o converted with r.
+func ConvertOverrides(o *firestore.PriceOverrides, r Rate) *firestore.PriceOverrides {
+	return o.Map(func(m firestore.Money) firestore.Money {
+		return Convert(m, r)
+	})
+}
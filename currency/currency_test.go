@@ -0,0 +1,116 @@
+package currency_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/royge/gostcalc/currency"
+	"github.com/royge/gostcalc/firestore"
+)
+
+const rates = `date,from,to,rate
+2026-09-01,USD,PHP,56.50
+2026-10-01,USD,PHP,57.25
+2026-10-01,EUR,USD,1.25
+`
+
+func readTable(t *testing.T) currency.Table {
+	t.Helper()
+
+	table, err := currency.ReadTable(strings.NewReader(rates))
+	if err != nil {
+		t.Fatalf("unable to read rates: %v", err)
+	}
+
+	return table
+}
+
+func TestTable_Lookup(t *testing.T) {
+	table := readTable(t)
+
+	tt := []struct {
+		name string
+		to   string
+		date string
+		want string
+	}{
+		{"latest rate", "PHP", "2026-10-19", "1 USD = 57.250000 PHP as of 2026-10-01"},
+		{"rate on date", "PHP", "2026-09-15", "1 USD = 56.500000 PHP as of 2026-09-01"},
+		{"inverse rate", "EUR", "2026-10-19", "1 USD = 0.800000 EUR as of 2026-10-01"},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			date, _ := time.Parse(currency.DateLayout, tc.date)
+
+			rate, err := table.Lookup(currency.Base, tc.to, date)
+			if err != nil {
+				t.Fatalf("unable to lookup rate: %v", err)
+			}
+
+			if got := rate.String(); tc.want != got {
+				t.Errorf("want Lookup() = %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestTable_Lookup_NoRate(t *testing.T) {
+	table := readTable(t)
+
+	date, _ := time.Parse(currency.DateLayout, "2026-08-01")
+
+	_, err := table.Lookup(currency.Base, "PHP", date)
+	if !errors.Is(err, currency.ErrNoRate) {
+		t.Errorf("want Lookup() error %v, got %v", currency.ErrNoRate, err)
+	}
+}
+
+func TestConvertPrices(t *testing.T) {
+	table := readTable(t)
+
+	rate, err := table.Lookup(currency.Base, "PHP", time.Now())
+	if err != nil {
+		t.Fatalf("unable to lookup rate: %v", err)
+	}
+
+	prices := currency.ConvertPrices(firestore.DefaultPrices, rate)
+
+	// 0.18 * 57.25
+	want := firestore.Money(10305000)
+
+	if prices.Write != want {
+		t.Errorf("want converted write price %v, got %v", want, prices.Write)
+	}
+}
+
+func TestConvertOverrides(t *testing.T) {
+	table := readTable(t)
+
+	rate, err := table.Lookup(currency.Base, "PHP", time.Now())
+	if err != nil {
+		t.Fatalf("unable to lookup rate: %v", err)
+	}
+
+	write, free := firestore.WriteUnitPrice, firestore.Money(0)
+	o := currency.ConvertOverrides(&firestore.PriceOverrides{Write: &write, Read: &free}, rate)
+
+	// 0.18 * 57.25
+	if o.Write == nil || *o.Write != firestore.Money(10305000) {
+		t.Errorf("want converted write price 10.305, got %v", o.Write)
+	}
+	if o.Read == nil || *o.Read != 0 {
+		t.Errorf("want a free read price kept, got %v", o.Read)
+	}
+	if o.Delete != nil {
+		t.Errorf("want an unset delete price kept unset, got %v", *o.Delete)
+	}
+
+	if currency.ConvertOverrides(nil, rate) != nil {
+		t.Error("want no overrides converted from none")
+	}
+}
@@ -0,0 +1,116 @@
+package currency
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+// SKU names accepted in price override files.
+const (
+	SKUWrite   = "write"
+	SKURead    = "read"
+	SKUDelete  = "delete"
+	SKUStorage = "storage"
+	SKUIngress = "ingress"
+	SKUBackup  = "backup"
+	SKURestore = "restore"
+	SKUPITR    = "pitr"
+
+	SKUExportStorage = "export_storage"
+)
+
+var skus = []string{
+	SKUWrite, SKURead, SKUDelete, SKUStorage, SKUIngress,
+	SKUBackup, SKURestore, SKUPITR, SKUExportStorage,
+}
+
+// Overrides holds published local-currency unit prices by currency code and
+// SKU name.
+type Overrides map[string]map[string]firestore.Money
+
+// ReadOverrides reads a CSV price override file with "currency,sku,price"
+// columns. A header row is skipped when present.
+func ReadOverrides(r io.Reader) (Overrides, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 3
+	cr.TrimLeadingSpace = true
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read price overrides: %w", err)
+	}
+
+	o := Overrides{}
+	for i, rec := range records {
+		if i == 0 && strings.EqualFold(rec[0], "currency") {
+			continue
+		}
+
+		code, sku := strings.ToUpper(rec[0]), strings.ToLower(rec[1])
+
+		switch sku {
+		case SKUWrite, SKURead, SKUDelete, SKUStorage, SKUIngress,
+			SKUBackup, SKURestore, SKUPITR, SKUExportStorage:
+		default:
+			return nil, fmt.Errorf("line %d: unknown sku %q", i+1, rec[1])
+		}
+
+		price, err := firestore.ParsePrice(rec[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		if o[code] == nil {
+			o[code] = map[string]firestore.Money{}
+		}
+		o[code][sku] = price
+	}
+
+	return o, nil
+}
+
+// Missing returns the SKUs without a published price in currency.
+func (o Overrides) Missing(currency string) []string {
+	var missing []string
+	for _, sku := range skus {
+		if _, ok := o[strings.ToUpper(currency)][sku]; !ok {
+			missing = append(missing, sku)
+		}
+	}
+
+	return missing
+}
+
+// Apply returns p with the published prices of currency replacing the
+// converted ones.
+func (o Overrides) Apply(currency string, p firestore.PriceList) firestore.PriceList {
+	for sku, price := range o[strings.ToUpper(currency)] {
+		switch sku {
+		case SKUWrite:
+			p.Write = price
+		case SKURead:
+			p.Read = price
+		case SKUDelete:
+			p.Delete = price
+		case SKUStorage:
+			p.Storage = price
+		case SKUIngress:
+			p.Ingress = price
+		case SKUBackup:
+			p.Backup = price
+		case SKURestore:
+			p.Restore = price
+		case SKUPITR:
+			p.PITR = price
+		case SKUExportStorage:
+			p.ExportStorage = price
+		}
+	}
+
+	return p
+}
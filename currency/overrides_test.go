@@ -0,0 +1,84 @@
+package currency_test
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/royge/gostcalc/currency"
+	"github.com/royge/gostcalc/firestore"
+)
+
+func TestOverrides_Apply(t *testing.T) {
+	o, err := currency.ReadOverrides(strings.NewReader(`currency,sku,price
+EUR,write,0.166
+eur,Storage,0.17
+`))
+	if err != nil {
+		t.Fatalf("unable to read overrides: %v", err)
+	}
+
+	got := o.Apply("EUR", firestore.DefaultPrices)
+
+	want := firestore.DefaultPrices
+	want.Write = 166000
+	want.Storage = 17 * firestore.Cent
+
+	if want != got {
+		t.Errorf("want Apply() = %+v, got %+v", want, got)
+	}
+}
+
+func TestReadOverrides_UnknownSKU(t *testing.T) {
+	_, err := currency.ReadOverrides(strings.NewReader("EUR,egress,0.12\n"))
+	if err == nil {
+		t.Error("want ReadOverrides() error for unknown sku, got nil")
+	}
+}
+
+func TestOverrides_ApplyZero(t *testing.T) {
+	o, err := currency.ReadOverrides(strings.NewReader("USD,write,0\n"))
+	if err != nil {
+		t.Fatalf("unable to read overrides: %v", err)
+	}
+
+	p := o.Apply("USD", firestore.DefaultPrices)
+
+	calc := &firestore.MonthlyWriteCalculator{
+		D: &firestore.DailyWriteCalculator{Price: p.Write},
+	}
+
+	got, err := calc.Calculate(context.Background(), big.NewInt(1000000))
+	if err != nil {
+		t.Fatalf("unable to calculate: %v", err)
+	}
+
+	if got != 0 {
+		t.Errorf("want free writes, got %v", got)
+	}
+}
+
+func TestOverrides_Missing(t *testing.T) {
+	o, err := currency.ReadOverrides(strings.NewReader(`EUR,write,0.166
+EUR,read,0.055
+`))
+	if err != nil {
+		t.Fatalf("unable to read overrides: %v", err)
+	}
+
+	got := o.Missing("eur")
+	want := []string{"delete", "storage", "ingress", "backup", "restore", "pitr", "export_storage"}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want Missing() = %v, got %v", want, got)
+	}
+}
+
+func TestReadOverrides_Negative(t *testing.T) {
+	_, err := currency.ReadOverrides(strings.NewReader("EUR,write,-0.1\n"))
+	if err == nil {
+		t.Error("want ReadOverrides() error for a negative price, got nil")
+	}
+}
@@ -0,0 +1,233 @@
+// Package discount applies negotiated discounts, committed use and credits to
+// estimates.
+package discount
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+)
+
+// Terms are the discounts, spend commitment and credits of a billing account.
+// Amounts are in the report currency.
+type Terms struct {
+	// Discounts is the percentage discount of each category.
+	Discounts map[scenario.Category]float64 `json:"discounts,omitempty"`
+
+	// Commitment is the committed-use spend commitment, nil when there is
+	// none.
+	Commitment *Commitment `json:"commitment,omitempty"`
+
+	// Credits are the promotional credit balances, used after the discounts.
+	Credits []*Credit `json:"credits,omitempty"`
+}
+
+// Commitment is a monthly spend commitment. The committed amount is always
+// billed and covers the usage it buys at its discount, the usage beyond it is
+// billed at the discounted prices.
+type Commitment struct {
+	// Monthly is the committed spend of every month.
+	Monthly firestore.Money `json:"monthly"`
+
+	// DiscountPercent is the discount of the usage the commitment covers.
+	DiscountPercent float64 `json:"discount_percent"`
+
+	// Months is the term of the commitment from the start of the forecast,
+	// the whole forecast when zero.
+	Months int `json:"months,omitempty"`
+}
+
+// Credit is a promotional credit balance.
+type Credit struct {
+	// Name identifies the credit.
+	Name string `json:"name"`
+
+	// Amount is the balance at the start of the forecast.
+	Amount firestore.Money `json:"amount"`
+
+	// ExpiresAfterMonths is the number of months the credit can be used
+	// from the start of the forecast, without expiry when zero.
+	ExpiresAfterMonths int `json:"expires_after_months,omitempty"`
+}
+
+// Read decodes and validates JSON terms. Category names are case
+// insensitive.
+func Read(r io.Reader) (*Terms, error) {
+	d := json.NewDecoder(r)
+	d.DisallowUnknownFields()
+
+	t := &Terms{}
+	if err := d.Decode(t); err != nil {
+		return nil, fmt.Errorf("unable to decode terms: %w", err)
+	}
+
+	discounts := map[scenario.Category]float64{}
+	for name, pct := range t.Discounts {
+		c, ok := scenario.ParseCategory(string(name))
+		if !ok {
+			return nil, fmt.Errorf("invalid terms: unknown category %q", name)
+		}
+		if pct < 0 || pct > 100 {
+			return nil, fmt.Errorf("invalid terms: %s discount %v is not a percentage", c, pct)
+		}
+		discounts[c] = pct
+	}
+	if len(discounts) > 0 {
+		t.Discounts = discounts
+	}
+
+	if c := t.Commitment; c != nil {
+		switch {
+		case c.Monthly < 0:
+			return nil, fmt.Errorf("invalid terms: commitment monthly %v is negative", c.Monthly)
+		case c.DiscountPercent < 0 || c.DiscountPercent >= 100:
+			return nil, fmt.Errorf("invalid terms: commitment discount %v is not a percentage", c.DiscountPercent)
+		case c.Months < 0:
+			return nil, fmt.Errorf("invalid terms: commitment months %d is negative", c.Months)
+		}
+	}
+
+	for i, c := range t.Credits {
+		switch {
+		case c == nil:
+			return nil, fmt.Errorf("invalid terms: credit %d is empty", i+1)
+		case c.Amount < 0:
+			return nil, fmt.Errorf("invalid terms: credit %q amount %v is negative", c.Name, c.Amount)
+		case c.ExpiresAfterMonths < 0:
+			return nil, fmt.Errorf("invalid terms: credit %q expiry %d is negative", c.Name, c.ExpiresAfterMonths)
+		}
+	}
+
+	return t, nil
+}
+
+// Load reads a JSON terms file.
+func Load(path string) (*Terms, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open terms: %w", err)
+	}
+	defer f.Close()
+
+	t, err := Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return t, nil
+}
+
+// Month is the net cost of a month of a forecast.
+type Month struct {
+	// Month is the number of the month from 1.
+	Month int
+
+	// List is the cost at the prices of the estimate.
+	List firestore.Money
+
+	// Discount is the saving of the discounts and the commitment, negative
+	// when the commitment is not used up.
+	Discount firestore.Money
+
+	// Credits is the amount of credits used.
+	Credits firestore.Money
+
+	// OutOfPocket is the amount paid after the credits.
+	OutOfPocket firestore.Money
+
+	// Balance is the credit balance left at the end of the month, without
+	// expired credits.
+	Balance firestore.Money
+}
+
+// Forecast applies t to the monthly estimate e over months months. The list
+// cost grows by growth percent every month. The category discounts apply
+// first, then the commitment, and the credits pay what is left, the ones
+// expiring first before the others.
+func (t *Terms) Forecast(e scenario.Estimate, months int, growth float64) []Month {
+	credits := make([]*Credit, len(t.Credits))
+	for i, c := range t.Credits {
+		cc := *c
+		credits[i] = &cc
+	}
+	sort.SliceStable(credits, func(a, b int) bool {
+		return expiry(credits[a]) < expiry(credits[b])
+	})
+
+	forecast := make([]Month, months)
+	for i := range forecast {
+		m := Month{Month: i + 1}
+		factor := math.Pow(1+growth/100, float64(i))
+
+		for _, c := range scenario.Categories {
+			list := scale(e[c], factor)
+			m.List += list
+			m.Discount += scale(list, t.Discounts[c]/100)
+		}
+		net := m.List - m.Discount
+
+		if c := t.Commitment; c != nil && (c.Months == 0 || i < c.Months) {
+			charge := c.Monthly
+
+			// Usage the commitment buys at its discount.
+			covered := scale(c.Monthly, 100/(100-c.DiscountPercent))
+			if net > covered {
+				charge += net - covered
+			}
+
+			m.Discount += net - charge
+			net = charge
+		}
+
+		for _, c := range credits {
+			if expiry(c) <= i {
+				continue
+			}
+
+			used := c.Amount
+			if used > net-m.Credits {
+				used = net - m.Credits
+			}
+			c.Amount -= used
+			m.Credits += used
+		}
+		m.OutOfPocket = net - m.Credits
+
+		for _, c := range credits {
+			if expiry(c) > i+1 {
+				m.Balance += c.Amount
+			}
+		}
+
+		forecast[i] = m
+	}
+
+	return forecast
+}
+
+// Return the number of months c can be used.
+func expiry(c *Credit) int {
+	if c.ExpiresAfterMonths == 0 {
+		return math.MaxInt32
+	}
+
+	return c.ExpiresAfterMonths
+}
+
+// Multiply m by ratio, rounded to the billing unit.
+func scale(m firestore.Money, ratio float64) firestore.Money {
+	r := new(big.Rat).SetFloat64(ratio)
+	if r == nil {
+		return 0
+	}
+	r.Mul(r, new(big.Rat).SetFrac64(int64(m), int64(firestore.Dollar)))
+
+	return firestore.RatMoney(r).Round(firestore.BillingUnit, firestore.BillingRounding)
+}
@@ -0,0 +1,120 @@
+package discount_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/royge/gostcalc/discount"
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+)
+
+var estimate = scenario.Estimate{
+	scenario.CategoryRead:    100 * firestore.Dollar,
+	scenario.CategoryStorage: 50 * firestore.Dollar,
+}
+
+func TestTerms_Forecast(t *testing.T) {
+	terms, err := discount.Read(strings.NewReader(`{
+		"discounts": {"read": 10},
+		"commitment": {"monthly": "80.00", "discount_percent": 20, "months": 2},
+		"credits": [
+			{"name": "promo", "amount": "100.00"},
+			{"name": "startup", "amount": "150.00", "expires_after_months": 1}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("unable to read terms: %v", err)
+	}
+
+	// The list cost of 150.00 is 140.00 after the read discount. The
+	// commitment of 80.00 covers 100.00 of it, so 120.00 is billed while it
+	// lasts.
+	want := []discount.Month{
+		// The expiring credit is used first, its 30.00 left expires.
+		{1, 150 * firestore.Dollar, 30 * firestore.Dollar, 120 * firestore.Dollar, 0, 100 * firestore.Dollar},
+		{2, 150 * firestore.Dollar, 30 * firestore.Dollar, 100 * firestore.Dollar, 20 * firestore.Dollar, 0},
+		{3, 150 * firestore.Dollar, 10 * firestore.Dollar, 0, 140 * firestore.Dollar, 0},
+	}
+
+	got := terms.Forecast(estimate, 3, 0)
+	if len(got) != len(want) {
+		t.Fatalf("want %d months, got %d", len(want), len(got))
+	}
+
+	for i := range want {
+		if want[i] != got[i] {
+			t.Errorf("want month %+v, got %+v", want[i], got[i])
+		}
+	}
+}
+
+func TestTerms_Forecast_Commitment(t *testing.T) {
+	tt := []struct {
+		name     string
+		monthly  firestore.Money
+		discount firestore.Money
+		paid     firestore.Money
+	}{
+		// 100.00 covers 125.00 of the 150.00.
+		{"partly covered", 100 * firestore.Dollar, 25 * firestore.Dollar, 125 * firestore.Dollar},
+		// 200.00 is billed for 150.00 of usage.
+		{"not used up", 200 * firestore.Dollar, -50 * firestore.Dollar, 200 * firestore.Dollar},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			terms := &discount.Terms{
+				Commitment: &discount.Commitment{Monthly: tc.monthly, DiscountPercent: 20},
+			}
+
+			m := terms.Forecast(estimate, 1, 0)[0]
+			if tc.discount != m.Discount {
+				t.Errorf("want discount %v, got %v", tc.discount, m.Discount)
+			}
+			if tc.paid != m.OutOfPocket {
+				t.Errorf("want out-of-pocket spend %v, got %v", tc.paid, m.OutOfPocket)
+			}
+		})
+	}
+}
+
+func TestTerms_Forecast_Growth(t *testing.T) {
+	terms := &discount.Terms{}
+
+	got := terms.Forecast(estimate, 3, 10)
+
+	// 150.00 growing 10% a month.
+	for i, want := range []firestore.Money{15000, 16500, 18150} {
+		if want*firestore.Cent != got[i].List {
+			t.Errorf("want month %d list cost %v, got %v", i+1, want*firestore.Cent, got[i].List)
+		}
+	}
+}
+
+func TestRead_Invalid(t *testing.T) {
+	tt := []struct {
+		name  string
+		input string
+	}{
+		{"unknown field", `{"discount": {"read": 10}}`},
+		{"unknown category", `{"discounts": {"reads": 10}}`},
+		{"discount percentage", `{"discounts": {"read": 110}}`},
+		{"commitment discount", `{"commitment": {"monthly": "10.00", "discount_percent": 100}}`},
+		{"negative commitment", `{"commitment": {"monthly": "-10.00"}}`},
+		{"negative credit", `{"credits": [{"name": "promo", "amount": "-1.00"}]}`},
+		{"negative expiry", `{"credits": [{"name": "promo", "amount": "1.00", "expires_after_months": -1}]}`},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := discount.Read(strings.NewReader(tc.input)); err == nil {
+				t.Error("want error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+// Package emulator samples documents from a Firestore emulator through its
+// REST API.
+package emulator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/royge/gostcalc/export"
+)
+
+const (
+	// DefaultDatabase is the ID of the default database.
+	DefaultDatabase = "(default)"
+
+	// DefaultPageSize is the number of documents requested per page.
+	DefaultPageSize = 300
+)
+
+// Client lists collections and documents of an emulator.
+type Client struct {
+	// Host is the emulator host and port, e.g. localhost:8080.
+	Host string
+
+	// Project is the project ID of the data.
+	Project string
+
+	// Database is the database ID, DefaultDatabase when empty.
+	Database string
+
+	// Limit is the maximum number of documents read per collection, all
+	// documents when zero.
+	Limit int
+
+	// HTTP is the client of requests, http.DefaultClient when nil.
+	HTTP *http.Client
+}
+
+// Root returns the resource name of the documents of the database.
+func (c *Client) Root() string {
+	db := c.Database
+	if db == "" {
+		db = DefaultDatabase
+	}
+
+	return fmt.Sprintf("projects/%s/databases/%s/documents", c.Project, db)
+}
+
+// Walk calls fn with every document of every collection. The documents of a
+// collection come before the documents of their subcollections.
+func (c *Client) Walk(ctx context.Context, fn func(*export.Entity) error) error {
+	return c.walk(ctx, "", fn)
+}
+
+// Walk the collections of the document at path, the root when empty.
+func (c *Client) walk(ctx context.Context, path string, fn func(*export.Entity) error) error {
+	ids, err := c.CollectionIDs(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		collection := id
+		if path != "" {
+			collection = path + "/" + id
+		}
+
+		var paths []string
+		err := c.Documents(ctx, collection, func(docPath string, e *export.Entity) error {
+			paths = append(paths, docPath)
+			if e == nil {
+				return nil
+			}
+			return fn(e)
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, p := range paths {
+			if err := c.walk(ctx, p, fn); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// CollectionIDs returns the IDs of the collections of the document at path,
+// or the root collections when path is empty.
+func (c *Client) CollectionIDs(ctx context.Context, path string) ([]string, error) {
+	parent := c.Root()
+	if path != "" {
+		parent += "/" + path
+	}
+
+	var ids []string
+	token := ""
+	for {
+		body, err := json.Marshal(map[string]interface{}{
+			"pageSize":  DefaultPageSize,
+			"pageToken": token,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var res struct {
+			CollectionIDs []string `json:"collectionIds"`
+			NextPageToken string   `json:"nextPageToken"`
+		}
+		if err := c.do(ctx, http.MethodPost, parent+":listCollectionIds", body, &res); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, res.CollectionIDs...)
+		if token = res.NextPageToken; token == "" {
+			return ids, nil
+		}
+	}
+}
+
+// Documents calls fn with the path of every document of collection, up to
+// Limit. Missing documents, which only have subcollections, are passed with a
+// nil entity.
+func (c *Client) Documents(
+	ctx context.Context,
+	collection string,
+	fn func(path string, e *export.Entity) error,
+) error {
+	prefix := c.Root() + "/"
+
+	n := 0
+	token := ""
+	for {
+		size := DefaultPageSize
+		if c.Limit > 0 && c.Limit-n < size {
+			size = c.Limit - n
+		}
+
+		q := url.Values{}
+		q.Set("pageSize", strconv.Itoa(size))
+		q.Set("showMissing", "true")
+		if token != "" {
+			q.Set("pageToken", token)
+		}
+
+		var res struct {
+			Documents     []document `json:"documents"`
+			NextPageToken string     `json:"nextPageToken"`
+		}
+		if err := c.do(ctx, http.MethodGet, prefix+collection+"?"+q.Encode(), nil, &res); err != nil {
+			return err
+		}
+
+		for _, d := range res.Documents {
+			path := strings.TrimPrefix(d.Name, prefix)
+
+			var e *export.Entity
+			if d.CreateTime != "" {
+				data, err := DecodeFields(d.Fields)
+				if err != nil {
+					return fmt.Errorf("%s: %w", path, err)
+				}
+				e = &export.Entity{Path: path, Data: data}
+				n++
+			}
+
+			if err := fn(path, e); err != nil {
+				return err
+			}
+		}
+
+		token = res.NextPageToken
+		if token == "" || (c.Limit > 0 && n >= c.Limit) {
+			return nil
+		}
+	}
+}
+
+// document is a document resource of the REST API.
+type document struct {
+	Name       string                     `json:"name"`
+	Fields     map[string]json.RawMessage `json:"fields"`
+	CreateTime string                     `json:"createTime"`
+}
+
+// Send a request to the emulator and decode the JSON response into v.
+func (c *Client) do(ctx context.Context, method, resource string, body []byte, v interface{}) error {
+	u := "http://" + c.Host + "/v1/" + resource
+
+	req, err := http.NewRequest(method, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+
+	// The emulator bypasses security rules for the owner token.
+	req.Header.Set("Authorization", "Bearer owner")
+
+	hc := c.HTTP
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+
+	res, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: %s: %s", method, resource, res.Status, bytes.TrimSpace(b))
+	}
+
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,143 @@
+package emulator_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/royge/gostcalc/emulator"
+	"github.com/royge/gostcalc/export"
+)
+
+const root = "/v1/projects/demo/databases/(default)/documents"
+
+// fakeEmulator serves collections of documents, one document per page.
+type fakeEmulator struct {
+	// collections by parent document path, "" for the root.
+	collections map[string][]string
+
+	// documents by collection path. Documents without fields are missing.
+	documents map[string][]string
+}
+
+func (f *fakeEmulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer owner" {
+		http.Error(w, `{"error": "denied"}`, http.StatusForbidden)
+		return
+	}
+
+	path := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, root), "/")
+
+	if strings.HasSuffix(path, ":listCollectionIds") {
+		parent := strings.TrimSuffix(path, ":listCollectionIds")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"collectionIds": f.collections[parent],
+		})
+		return
+	}
+
+	docs := f.documents[path]
+	page, _ := strconv.Atoi(r.URL.Query().Get("pageToken"))
+	if page >= len(docs) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{})
+		return
+	}
+
+	doc := map[string]interface{}{
+		"name": strings.TrimPrefix(root, "/v1/") + "/" + path + "/" + strings.TrimPrefix(docs[page], "?"),
+	}
+	if !strings.HasPrefix(docs[page], "?") {
+		doc["createTime"] = "2026-01-01T00:00:00Z"
+		doc["fields"] = map[string]interface{}{
+			"name": map[string]interface{}{"stringValue": docs[page]},
+		}
+	}
+
+	res := map[string]interface{}{"documents": []interface{}{doc}}
+	if page+1 < len(docs) {
+		res["nextPageToken"] = fmt.Sprint(page + 1)
+	}
+
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func TestWalk(t *testing.T) {
+	srv := httptest.NewServer(&fakeEmulator{
+		collections: map[string][]string{
+			"":        {"users", "teams"},
+			"users/a": {"logs"},
+			"teams/t": {"logs"},
+		},
+		documents: map[string][]string{
+			"users":        {"a", "b", "c"},
+			"users/a/logs": {"1"},
+			"teams":        {"?t"}, // missing, has subcollections
+			"teams/t/logs": {"2", "3"},
+		},
+	})
+	defer srv.Close()
+
+	tt := []struct {
+		name  string
+		limit int
+		want  []string
+	}{
+		{"all", 0, []string{"users/a", "users/b", "users/c", "users/a/logs/1", "teams/t/logs/2", "teams/t/logs/3"}},
+		{"limit", 1, []string{"users/a", "users/a/logs/1", "teams/t/logs/2"}},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			c := &emulator.Client{
+				Host:    strings.TrimPrefix(srv.URL, "http://"),
+				Project: "demo",
+				Limit:   tc.limit,
+			}
+
+			var got []string
+			err := c.Walk(context.Background(), func(e *export.Entity) error {
+				if e.Data["name"] != e.Path[strings.LastIndex(e.Path, "/")+1:] {
+					t.Errorf("unexpected data %v of %v", e.Data, e.Path)
+				}
+				got = append(got, e.Path)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestWalkError(t *testing.T) {
+	srv := httptest.NewServer(&fakeEmulator{})
+	defer srv.Close()
+
+	c := &emulator.Client{Host: strings.TrimPrefix(srv.URL, "http://"), Project: "demo"}
+	c.HTTP = &http.Client{Transport: stripAuth{}}
+
+	err := c.Walk(context.Background(), func(*export.Entity) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("want 403 error, got %v", err)
+	}
+}
+
+// stripAuth sends requests without credentials.
+type stripAuth struct{}
+
+func (stripAuth) RoundTrip(r *http.Request) (*http.Response, error) {
+	r.Header.Del("Authorization")
+	return http.DefaultTransport.RoundTrip(r)
+}
@@ -0,0 +1,139 @@
+package emulator
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+// ErrUnknownValue is returned for typed values of an unknown type.
+var ErrUnknownValue = errors.New("unknown value type")
+
+// DecodeFields converts the typed fields of a REST API document into document
+// data.
+func DecodeFields(fields map[string]json.RawMessage) (map[string]interface{}, error) {
+	data := make(map[string]interface{}, len(fields))
+
+	for name, raw := range fields {
+		v, err := DecodeValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", name, err)
+		}
+		data[name] = v
+	}
+
+	return data, nil
+}
+
+// DecodeValue converts a typed REST API Value, e.g. {"integerValue": "1"},
+// into a document value.
+func DecodeValue(raw json.RawMessage) (interface{}, error) {
+	var typed map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &typed); err != nil {
+		return nil, err
+	}
+
+	if len(typed) != 1 {
+		return nil, fmt.Errorf("%w: want one type, got %d", ErrUnknownValue, len(typed))
+	}
+
+	for typ, v := range typed {
+		switch typ {
+		case "nullValue":
+			return nil, nil
+		case "booleanValue":
+			var b bool
+			err := json.Unmarshal(v, &b)
+			return b, err
+		case "integerValue":
+			// 64-bit integers are strings in JSON.
+			var s string
+			if err := json.Unmarshal(v, &s); err != nil {
+				return nil, err
+			}
+			return strconv.ParseInt(s, 10, 64)
+		case "doubleValue":
+			return decodeDouble(v)
+		case "timestampValue":
+			var s string
+			if err := json.Unmarshal(v, &s); err != nil {
+				return nil, err
+			}
+			return time.Parse(time.RFC3339Nano, s)
+		case "stringValue":
+			var s string
+			err := json.Unmarshal(v, &s)
+			return s, err
+		case "bytesValue":
+			var s string
+			if err := json.Unmarshal(v, &s); err != nil {
+				return nil, err
+			}
+			return base64.StdEncoding.DecodeString(s)
+		case "referenceValue":
+			var s string
+			if err := json.Unmarshal(v, &s); err != nil {
+				return nil, err
+			}
+			// Keep the document path, like exports do.
+			if i := strings.Index(s, "/documents/"); i >= 0 {
+				s = s[i+len("/documents/"):]
+			}
+			return s, nil
+		case "geoPointValue":
+			var p firestore.GeoPoint
+			err := json.Unmarshal(v, &p)
+			return p, err
+		case "arrayValue":
+			var a struct {
+				Values []json.RawMessage `json:"values"`
+			}
+			if err := json.Unmarshal(v, &a); err != nil {
+				return nil, err
+			}
+
+			values := make([]interface{}, 0, len(a.Values))
+			for _, raw := range a.Values {
+				e, err := DecodeValue(raw)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, e)
+			}
+			return values, nil
+		case "mapValue":
+			var m struct {
+				Fields map[string]json.RawMessage `json:"fields"`
+			}
+			if err := json.Unmarshal(v, &m); err != nil {
+				return nil, err
+			}
+			return DecodeFields(m.Fields)
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnknownValue, typ)
+		}
+	}
+
+	return nil, nil
+}
+
+// Doubles are numbers, or "NaN", "Infinity" and "-Infinity" strings.
+func decodeDouble(v json.RawMessage) (float64, error) {
+	var f float64
+	if err := json.Unmarshal(v, &f); err == nil {
+		return f, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(v, &s); err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseFloat(s, 64)
+}
@@ -0,0 +1,79 @@
+package emulator_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/royge/gostcalc/emulator"
+	"github.com/royge/gostcalc/firestore"
+)
+
+func TestDecodeValue(t *testing.T) {
+	tt := []struct {
+		name  string
+		input string
+		want  interface{}
+	}{
+		{"null", `{"nullValue": null}`, nil},
+		{"boolean", `{"booleanValue": true}`, true},
+		{"integer", `{"integerValue": "9007199254740993"}`, int64(9007199254740993)},
+		{"double", `{"doubleValue": 1.5}`, 1.5},
+		{"infinity", `{"doubleValue": "Infinity"}`, math.Inf(1)},
+		{
+			"timestamp",
+			`{"timestampValue": "2026-01-02T03:04:05.123Z"}`,
+			time.Date(2026, 1, 2, 3, 4, 5, 123000000, time.UTC),
+		},
+		{"string", `{"stringValue": "hello"}`, "hello"},
+		{"bytes", `{"bytesValue": "AQI="}`, []byte{1, 2}},
+		{
+			"reference",
+			`{"referenceValue": "projects/p/databases/(default)/documents/users/a"}`,
+			"users/a",
+		},
+		{
+			"geopoint",
+			`{"geoPointValue": {"latitude": 14.5, "longitude": 121}}`,
+			firestore.GeoPoint{Latitude: 14.5, Longitude: 121},
+		},
+		{
+			"array",
+			`{"arrayValue": {"values": [{"stringValue": "a"}, {"integerValue": "1"}]}}`,
+			[]interface{}{"a", int64(1)},
+		},
+		{"empty array", `{"arrayValue": {}}`, []interface{}{}},
+		{
+			"map",
+			`{"mapValue": {"fields": {"source": {"stringValue": "web"}}}}`,
+			map[string]interface{}{"source": "web"},
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := emulator.DecodeValue(json.RawMessage(tc.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %#v, got %#v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDecodeValueUnknown(t *testing.T) {
+	for _, input := range []string{`{"vectorValue": {}}`, `{}`} {
+		_, err := emulator.DecodeValue(json.RawMessage(input))
+		if !errors.Is(err, emulator.ErrUnknownValue) {
+			t.Errorf("%s: want ErrUnknownValue, got %v", input, err)
+		}
+	}
+}
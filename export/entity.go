@@ -0,0 +1,414 @@
+package export
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+// Protocol buffer wire types.
+const (
+	wireVarint     = 0
+	wireFixed64    = 1
+	wireBytes      = 2
+	wireStartGroup = 3
+	wireEndGroup   = 4
+	wireFixed32    = 5
+)
+
+// Property meanings of the entity format used by exports.
+const (
+	meaningTimestamp   = 7
+	meaningBlob        = 14
+	meaningByteString  = 16
+	meaningEntityProto = 19
+	meaningEmptyList   = 24
+)
+
+// field is a decoded protocol buffer field. Groups are returned as bytes of
+// their content.
+type field struct {
+	num   int
+	wire  int
+	value uint64
+	bytes []byte
+}
+
+// decoder reads the fields of a protocol buffer message.
+type decoder struct {
+	b []byte
+}
+
+func (d *decoder) done() bool {
+	return len(d.b) == 0
+}
+
+func (d *decoder) varint() (uint64, error) {
+	v, n := binary.Uvarint(d.b)
+	if n <= 0 {
+		return 0, fmt.Errorf("%w: bad varint", ErrCorrupt)
+	}
+	d.b = d.b[n:]
+
+	return v, nil
+}
+
+func (d *decoder) take(n int) ([]byte, error) {
+	if n < 0 || n > len(d.b) {
+		return nil, fmt.Errorf("%w: truncated field", ErrCorrupt)
+	}
+
+	b := d.b[:n]
+	d.b = d.b[n:]
+
+	return b, nil
+}
+
+// Read the next field.
+func (d *decoder) next() (field, error) {
+	tag, err := d.varint()
+	if err != nil {
+		return field{}, err
+	}
+
+	f := field{num: int(tag >> 3), wire: int(tag & 7)}
+
+	switch f.wire {
+	case wireVarint:
+		f.value, err = d.varint()
+	case wireFixed64:
+		var b []byte
+		if b, err = d.take(8); err == nil {
+			f.value = binary.LittleEndian.Uint64(b)
+		}
+	case wireFixed32:
+		var b []byte
+		if b, err = d.take(4); err == nil {
+			f.value = uint64(binary.LittleEndian.Uint32(b))
+		}
+	case wireBytes:
+		var n uint64
+		if n, err = d.varint(); err == nil {
+			f.bytes, err = d.take(int(n))
+		}
+	case wireStartGroup:
+		f.bytes, err = d.group(f.num)
+	case wireEndGroup:
+		// Handled by group.
+	default:
+		err = fmt.Errorf("%w: unexpected wire type %d", ErrCorrupt, f.wire)
+	}
+
+	return f, err
+}
+
+// Read the content of group num up to its end tag.
+func (d *decoder) group(num int) ([]byte, error) {
+	start := d.b
+
+	for {
+		end := len(start) - len(d.b)
+
+		f, err := d.next()
+		if err != nil {
+			return nil, err
+		}
+
+		if f.wire == wireEndGroup {
+			if f.num != num {
+				return nil, fmt.Errorf("%w: mismatched group end", ErrCorrupt)
+			}
+			return start[:end], nil
+		}
+	}
+}
+
+// Entity is a decoded export entity.
+type Entity struct {
+	// Path is the document path, e.g. profiles/a/logs/b.
+	Path string
+
+	// Data contains the document fields and values.
+	Data map[string]interface{}
+}
+
+// CollectionGroup returns the ID of the collection of the entity.
+func (e *Entity) CollectionGroup() string {
+	parts := strings.Split(e.Path, "/")
+
+	return parts[len(parts)-2]
+}
+
+// Document returns the entity as a document.
+func (e *Entity) Document() *firestore.Document {
+	i := strings.LastIndex(e.Path, "/")
+
+	return &firestore.Document{
+		ID:         e.Path[i+1:],
+		Collection: e.Path[:i],
+		Data:       e.Data,
+	}
+}
+
+// DecodeEntity decodes an export record.
+func DecodeEntity(b []byte) (*Entity, error) {
+	e := &Entity{Data: map[string]interface{}{}}
+
+	d := &decoder{b: b}
+	for !d.done() {
+		f, err := d.next()
+		if err != nil {
+			return nil, err
+		}
+
+		switch f.num {
+		case 13: // key
+			if e.Path, err = decodeKey(f.bytes); err != nil {
+				return nil, err
+			}
+		case 14, 15: // property, raw_property
+			if err := decodeProperty(f.bytes, e.Data); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if e.Path == "" {
+		return nil, fmt.Errorf("%w: entity without key", ErrCorrupt)
+	}
+
+	return e, nil
+}
+
+// Decode the path of a Reference message.
+func decodeKey(b []byte) (string, error) {
+	var parts []string
+
+	d := &decoder{b: b}
+	for !d.done() {
+		f, err := d.next()
+		if err != nil {
+			return "", err
+		}
+		if f.num != 14 { // path
+			continue
+		}
+
+		p := &decoder{b: f.bytes}
+		for !p.done() {
+			el, err := p.next()
+			if err != nil {
+				return "", err
+			}
+			if el.num != 1 { // element
+				continue
+			}
+
+			kind, id, err := decodeElement(el.bytes, 2)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, kind, id)
+		}
+	}
+
+	if len(parts) == 0 {
+		return "", fmt.Errorf("%w: empty key", ErrCorrupt)
+	}
+
+	return strings.Join(parts, "/"), nil
+}
+
+// Decode the kind and ID of a path element whose type field is typ, and
+// whose id and name fields follow it.
+func decodeElement(b []byte, typ int) (kind, id string, err error) {
+	d := &decoder{b: b}
+	for !d.done() {
+		f, err := d.next()
+		if err != nil {
+			return "", "", err
+		}
+
+		switch f.num {
+		case typ:
+			kind = string(f.bytes)
+		case typ + 1:
+			id = strconv.FormatInt(int64(f.value), 10)
+		case typ + 2:
+			id = string(f.bytes)
+		}
+	}
+
+	return kind, id, nil
+}
+
+// Decode a Property message into data. Repeated properties are arrays.
+func decodeProperty(b []byte, data map[string]interface{}) error {
+	var (
+		name     string
+		meaning  int
+		multiple bool
+		value    []byte
+	)
+
+	d := &decoder{b: b}
+	for !d.done() {
+		f, err := d.next()
+		if err != nil {
+			return err
+		}
+
+		switch f.num {
+		case 1:
+			meaning = int(f.value)
+		case 3:
+			name = string(f.bytes)
+		case 4:
+			multiple = f.value != 0
+		case 5:
+			value = f.bytes
+		}
+	}
+
+	v, err := decodeValue(value, meaning)
+	if err != nil {
+		return fmt.Errorf("field %s: %w", name, err)
+	}
+
+	if meaning == meaningEmptyList {
+		data[name] = []interface{}{}
+		return nil
+	}
+
+	if !multiple {
+		data[name] = v
+		return nil
+	}
+
+	list, _ := data[name].([]interface{})
+	data[name] = append(list, v)
+
+	return nil
+}
+
+// Decode a PropertyValue message.
+func decodeValue(b []byte, meaning int) (interface{}, error) {
+	var v interface{}
+
+	d := &decoder{b: b}
+	for !d.done() {
+		f, err := d.next()
+		if err != nil {
+			return nil, err
+		}
+
+		switch f.num {
+		case 1: // int64
+			v = int64(f.value)
+			if meaning == meaningTimestamp {
+				v = time.Unix(0, int64(f.value)*int64(time.Microsecond)).UTC()
+			}
+		case 2: // boolean
+			v = f.value != 0
+		case 3: // string
+			switch meaning {
+			case meaningEntityProto:
+				e, err := decodeMap(f.bytes)
+				if err != nil {
+					return nil, err
+				}
+				v = e
+			case meaningBlob, meaningByteString:
+				v = append([]byte(nil), f.bytes...)
+			default:
+				v = string(f.bytes)
+			}
+		case 4: // double
+			v = math.Float64frombits(f.value)
+		case 5: // point
+			v, err = decodePoint(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+		case 12: // reference
+			v, err = decodeReference(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return v, nil
+}
+
+// Decode an embedded entity, which is a map value.
+func decodeMap(b []byte) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+
+	d := &decoder{b: b}
+	for !d.done() {
+		f, err := d.next()
+		if err != nil {
+			return nil, err
+		}
+
+		if f.num == 14 || f.num == 15 {
+			if err := decodeProperty(f.bytes, m); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// Decode a PointValue group.
+func decodePoint(b []byte) (firestore.GeoPoint, error) {
+	var p firestore.GeoPoint
+
+	d := &decoder{b: b}
+	for !d.done() {
+		f, err := d.next()
+		if err != nil {
+			return p, err
+		}
+
+		switch f.num {
+		case 6:
+			p.Latitude = math.Float64frombits(f.value)
+		case 7:
+			p.Longitude = math.Float64frombits(f.value)
+		}
+	}
+
+	return p, nil
+}
+
+// Decode a ReferenceValue group into the path of the referenced document.
+func decodeReference(b []byte) (string, error) {
+	var parts []string
+
+	d := &decoder{b: b}
+	for !d.done() {
+		f, err := d.next()
+		if err != nil {
+			return "", err
+		}
+		if f.num != 14 { // path element
+			continue
+		}
+
+		kind, id, err := decodeElement(f.bytes, 15)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, kind, id)
+	}
+
+	return strings.Join(parts, "/"), nil
+}
@@ -0,0 +1,93 @@
+package export_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/royge/gostcalc/export"
+	"github.com/royge/gostcalc/firestore"
+)
+
+func TestDecodeEntity(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	b := encodeEntity(
+		"profiles/AbC/logs/XyZ",
+		property("message", 0, false, bytesField(3, []byte("hello"))),
+		property("count", 0, false, varintField(1, 42)),
+		property("ratio", 0, false, doubleField(4, 0.5)),
+		property("done", 0, false, varintField(2, 1)),
+		property("created", 7, false, varintField(1, uint64(created.UnixNano()/1000))),
+		property("missing", 0, false, nil),
+		property("tags", 0, true, bytesField(3, []byte("a"))),
+		property("tags", 0, true, bytesField(3, []byte("b"))),
+		property("empty", 24, false, nil),
+		property("raw", 16, false, bytesField(3, []byte{1, 2})),
+		property("where", 0, false, groupField(5, join(doubleField(6, 14.5), doubleField(7, 121)))),
+		property("owner", 0, false, groupField(12, join(
+			bytesField(13, []byte("s~project")),
+			groupField(14, join(bytesField(15, []byte("profiles")), bytesField(17, []byte("AbC")))),
+		))),
+		property("meta", 19, false, bytesField(3, join(
+			bytesField(14, property("source", 0, false, bytesField(3, []byte("web")))),
+		))),
+	)
+
+	e, err := export.DecodeEntity(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &export.Entity{
+		Path: "profiles/AbC/logs/XyZ",
+		Data: map[string]interface{}{
+			"message": "hello",
+			"count":   int64(42),
+			"ratio":   0.5,
+			"done":    true,
+			"created": created,
+			"missing": nil,
+			"tags":    []interface{}{"a", "b"},
+			"empty":   []interface{}{},
+			"raw":     []byte{1, 2},
+			"where":   firestore.GeoPoint{Latitude: 14.5, Longitude: 121},
+			"owner":   "profiles/AbC",
+			"meta":    map[string]interface{}{"source": "web"},
+		},
+	}
+
+	if !reflect.DeepEqual(e, want) {
+		t.Errorf("want %#v, got %#v", want, e)
+	}
+
+	if got := e.CollectionGroup(); got != "logs" {
+		t.Errorf("want collection group logs, got %v", got)
+	}
+
+	doc := e.Document()
+	if doc.ID != "XyZ" || doc.Collection != "profiles/AbC/logs" {
+		t.Errorf("want document XyZ in profiles/AbC/logs, got %v in %v", doc.ID, doc.Collection)
+	}
+}
+
+func TestDecodeEntityCorrupt(t *testing.T) {
+	tt := []struct {
+		name  string
+		input []byte
+	}{
+		{"no key", property("a", 0, false, nil)},
+		{"truncated", encodeEntity("a/b")[:5]},
+		{"open group", tag(5, 3)},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := export.DecodeEntity(tc.input); err == nil {
+				t.Error("want error, got nil")
+			}
+		})
+	}
+}
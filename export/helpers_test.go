@@ -0,0 +1,146 @@
+package export_test
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"math"
+	"strings"
+
+	"github.com/royge/gostcalc/export"
+)
+
+// Encode records in the LevelDB log format.
+func encodeLog(records ...[]byte) []byte {
+	var out []byte
+	table := crc32.MakeTable(crc32.Castagnoli)
+
+	for _, r := range records {
+		first := true
+		for {
+			left := export.BlockSize - len(out)%export.BlockSize
+			if left < 7 {
+				out = append(out, make([]byte, left)...)
+				left = export.BlockSize
+			}
+
+			n := left - 7
+			last := n >= len(r)
+			if last {
+				n = len(r)
+			}
+
+			var typ byte
+			switch {
+			case first && last:
+				typ = 1
+			case first:
+				typ = 2
+			case last:
+				typ = 4
+			default:
+				typ = 3
+			}
+
+			crc := crc32.Update(crc32.Checksum([]byte{typ}, table), table, r[:n])
+			h := make([]byte, 7)
+			binary.LittleEndian.PutUint32(h, (crc>>15|crc<<17)+0xa282ead8)
+			binary.LittleEndian.PutUint16(h[4:], uint16(n))
+			h[6] = typ
+
+			out = append(out, h...)
+			out = append(out, r[:n]...)
+			r, first = r[n:], false
+
+			if last {
+				break
+			}
+		}
+	}
+
+	return out
+}
+
+func uvarint(v uint64) []byte {
+	b := make([]byte, binary.MaxVarintLen64)
+	return b[:binary.PutUvarint(b, v)]
+}
+
+func fixed64(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, v)
+	return b
+}
+
+func tag(num, wire int) []byte {
+	return uvarint(uint64(num<<3 | wire))
+}
+
+func varintField(num int, v uint64) []byte {
+	return append(tag(num, 0), uvarint(v)...)
+}
+
+func doubleField(num int, v float64) []byte {
+	return append(tag(num, 1), fixed64(math.Float64bits(v))...)
+}
+
+func bytesField(num int, b []byte) []byte {
+	out := append(tag(num, 2), uvarint(uint64(len(b)))...)
+	return append(out, b...)
+}
+
+func groupField(num int, b []byte) []byte {
+	out := append(tag(num, 3), b...)
+	return append(out, tag(num, 4)...)
+}
+
+func join(parts ...[]byte) []byte {
+	var out []byte
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
+
+// Encode an entity with the document path and properties.
+func encodeEntity(path string, props ...[]byte) []byte {
+	parts := strings.Split(path, "/")
+
+	var elements []byte
+	for i := 0; i < len(parts); i += 2 {
+		elements = append(elements, groupField(1, join(
+			bytesField(2, []byte(parts[i])),
+			bytesField(4, []byte(parts[i+1])),
+		))...)
+	}
+
+	key := join(
+		bytesField(13, []byte("s~project")),
+		bytesField(14, elements),
+	)
+
+	out := bytesField(13, key)
+	for _, p := range props {
+		out = append(out, bytesField(14, p)...)
+	}
+
+	return out
+}
+
+// Encode a property with a PropertyValue.
+func property(name string, meaning int, multiple bool, value []byte) []byte {
+	m := uint64(0)
+	if multiple {
+		m = 1
+	}
+
+	out := join(
+		bytesField(3, []byte(name)),
+		varintField(4, m),
+		bytesField(5, value),
+	)
+	if meaning != 0 {
+		out = append(varintField(1, uint64(meaning)), out...)
+	}
+
+	return out
+}
@@ -0,0 +1,143 @@
+// Package export reads Firestore managed export files and measures the
+// documents in them.
+package export
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+const (
+	// BlockSize is the size of a block of an export file.
+	BlockSize = 32 * 1024
+
+	// Size of a record header: checksum, length and type.
+	headerSize = 7
+)
+
+// Record types of the LevelDB log format.
+const (
+	recordZero = iota
+	recordFull
+	recordFirst
+	recordMiddle
+	recordLast
+)
+
+// ErrCorrupt is returned for export files that are not in the LevelDB log
+// format or fail a checksum.
+var ErrCorrupt = errors.New("corrupt export file")
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Reader reads the records of an export file, which is in the LevelDB log
+// format: records split into fragments that never cross a 32 KiB block.
+type Reader struct {
+	r     io.Reader
+	block []byte
+
+	// pos is the read position in block.
+	pos int
+}
+
+// NewReader returns a Reader of the records in r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: r}
+}
+
+// Next returns the next record, or io.EOF after the last one.
+func (r *Reader) Next() ([]byte, error) {
+	var record []byte
+	inRecord := false
+
+	for {
+		if len(r.block)-r.pos < headerSize {
+			// The rest of the block is a zero trailer.
+			if err := r.readBlock(); err != nil {
+				if err == io.EOF && inRecord {
+					return nil, fmt.Errorf("%w: truncated record", ErrCorrupt)
+				}
+				return nil, err
+			}
+			continue
+		}
+
+		h := r.block[r.pos : r.pos+headerSize]
+		sum := binary.LittleEndian.Uint32(h[0:4])
+		length := int(binary.LittleEndian.Uint16(h[4:6]))
+		typ := h[6]
+
+		if typ == recordZero && length == 0 {
+			// Preallocated space, skip the rest of the block.
+			r.pos = len(r.block)
+			continue
+		}
+
+		start := r.pos + headerSize
+		if start+length > len(r.block) {
+			return nil, fmt.Errorf("%w: fragment crosses block", ErrCorrupt)
+		}
+
+		data := r.block[start : start+length]
+		if unmask(sum) != crc32.Update(crc32.Checksum(h[6:7], crcTable), crcTable, data) {
+			return nil, fmt.Errorf("%w: checksum mismatch", ErrCorrupt)
+		}
+		r.pos = start + length
+
+		switch typ {
+		case recordFull:
+			if inRecord {
+				return nil, fmt.Errorf("%w: unexpected full record", ErrCorrupt)
+			}
+			return append([]byte(nil), data...), nil
+		case recordFirst:
+			if inRecord {
+				return nil, fmt.Errorf("%w: unexpected first fragment", ErrCorrupt)
+			}
+			record = append(record[:0], data...)
+			inRecord = true
+		case recordMiddle, recordLast:
+			if !inRecord {
+				return nil, fmt.Errorf("%w: fragment without first", ErrCorrupt)
+			}
+			record = append(record, data...)
+			if typ == recordLast {
+				return record, nil
+			}
+		default:
+			return nil, fmt.Errorf("%w: unknown record type %d", ErrCorrupt, typ)
+		}
+	}
+}
+
+// Read the next block. The last block of a file may be short.
+func (r *Reader) readBlock() error {
+	if r.block == nil {
+		r.block = make([]byte, BlockSize)
+	}
+
+	n, err := io.ReadFull(r.r, r.block[:BlockSize])
+	if err == io.ErrUnexpectedEOF {
+		err = nil
+	}
+	if n == 0 && err == nil {
+		err = io.EOF
+	}
+	if err != nil {
+		return err
+	}
+
+	r.block, r.pos = r.block[:n], 0
+
+	return nil
+}
+
+// Unmask a LevelDB checksum.
+func unmask(sum uint32) uint32 {
+	rot := sum - 0xa282ead8
+
+	return rot>>17 | rot<<15
+}
@@ -0,0 +1,78 @@
+package export_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/royge/gostcalc/export"
+)
+
+func TestReader(t *testing.T) {
+	records := [][]byte{
+		[]byte("first"),
+		bytes.Repeat([]byte("a"), export.BlockSize*2+100), // spans 3 blocks
+		{},
+		bytes.Repeat([]byte("b"), export.BlockSize-7-5), // leaves a trailer
+		[]byte("last"),
+	}
+
+	r := export.NewReader(bytes.NewReader(encodeLog(records...)))
+
+	for i, want := range records {
+		got, err := r.Next()
+		if err != nil {
+			t.Fatalf("record %d: %v", i, err)
+		}
+
+		if !bytes.Equal(got, want) {
+			t.Errorf("record %d: want %d bytes, got %d bytes", i, len(want), len(got))
+		}
+	}
+
+	if _, err := r.Next(); err != io.EOF {
+		t.Errorf("want io.EOF, got %v", err)
+	}
+}
+
+func TestReaderCorrupt(t *testing.T) {
+	tt := []struct {
+		name  string
+		input func() []byte
+	}{
+		{
+			"checksum",
+			func() []byte {
+				b := encodeLog([]byte("hello"))
+				b[len(b)-1] = 'x'
+				return b
+			},
+		},
+		{
+			"truncated",
+			func() []byte {
+				return encodeLog(bytes.Repeat([]byte("a"), export.BlockSize*2))[:export.BlockSize]
+			},
+		},
+		{
+			"length",
+			func() []byte {
+				b := encodeLog([]byte("hello"))
+				b[4] = 0xff
+				return b
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := export.NewReader(bytes.NewReader(tc.input())).Next()
+			if !errors.Is(err, export.ErrCorrupt) {
+				t.Errorf("want ErrCorrupt, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package export
+
+import (
+	"context"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+// Group is the measured documents of a collection group.
+type Group struct {
+	// Name is the collection ID shared by the collections of the group.
+	Name string
+
+	// Sizes of the documents in bytes.
+	Sizes []int64
+
+	// Total size of the documents in bytes.
+	Total int64
+
+	// Fields counts the documents that have each top-level field.
+	Fields map[string]int64
+
+	// Types counts the documents by the value type of each top-level field.
+	Types map[string]map[string]int64
+
+	// IndexEntries is the number of automatic single-field index entries of
+	// the documents.
+	IndexEntries int64
+}
+
+// Count returns the number of documents.
+func (g *Group) Count() int64 {
+	return int64(len(g.Sizes))
+}
+
+// Mean returns the mean document size in bytes, rounded up.
+func (g *Group) Mean() int64 {
+	if len(g.Sizes) == 0 {
+		return 0
+	}
+
+	return (g.Total + g.Count() - 1) / g.Count()
+}
+
+// Percentile returns the nearest-rank percentile q (0-100) of the document
+// sizes.
+func (g *Group) Percentile(q float64) int64 {
+	if len(g.Sizes) == 0 {
+		return 0
+	}
+
+	sizes := append([]int64(nil), g.Sizes...)
+	sort.Slice(sizes, func(i, j int) bool { return sizes[i] < sizes[j] })
+
+	rank := int(math.Ceil(q / 100 * float64(len(sizes))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sizes) {
+		rank = len(sizes)
+	}
+
+	return sizes[rank-1]
+}
+
+// Stats is the measured documents of an export by collection group.
+type Stats struct {
+	Groups map[string]*Group
+}
+
+// Add measures the document of e.
+func (s *Stats) Add(e *Entity) {
+	if s.Groups == nil {
+		s.Groups = map[string]*Group{}
+	}
+
+	name := e.CollectionGroup()
+	g, ok := s.Groups[name]
+	if !ok {
+		g = &Group{
+			Name:   name,
+			Fields: map[string]int64{},
+			Types:  map[string]map[string]int64{},
+		}
+		s.Groups[name] = g
+	}
+
+	size := e.Document().Size()
+	g.Sizes = append(g.Sizes, size)
+	g.Total += size
+
+	for f, v := range e.Data {
+		g.Fields[f]++
+
+		if g.Types[f] == nil {
+			g.Types[f] = map[string]int64{}
+		}
+		g.Types[f][ValueType(v)]++
+
+		g.IndexEntries += indexEntries(v)
+	}
+}
+
+// ValueType returns the Firestore type name of a document value.
+func ValueType(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case int, int64:
+		return "integer"
+	case float64:
+		return "double"
+	case string:
+		return "string"
+	case []byte:
+		return "bytes"
+	case time.Time:
+		return "timestamp"
+	case firestore.GeoPoint:
+		return "geopoint"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "map"
+	default:
+		return "unknown"
+	}
+}
+
+// Count the automatic single-field index entries of a value: ascending and
+// descending entries of every value and map subfield, and an array-contains
+// entry of every array element.
+func indexEntries(v interface{}) int64 {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		var n int64
+		for _, sub := range v {
+			n += indexEntries(sub)
+		}
+		return n
+	case []interface{}:
+		return int64(len(v))
+	default:
+		return 2
+	}
+}
+
+// Names returns the collection group names in order.
+func (s *Stats) Names() []string {
+	var names []string
+	for n := range s.Groups {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Read measures every entity of the export file in r.
+func (s *Stats) Read(ctx context.Context, r io.Reader) error {
+	lr := NewReader(r)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		record, err := lr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		e, err := DecodeEntity(record)
+		if err != nil {
+			return err
+		}
+
+		s.Add(e)
+	}
+}
+
+// Scan measures every output-N file in the export directory dir and its
+// subdirectories.
+func Scan(ctx context.Context, dir string) (*Stats, error) {
+	var files []string
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() && strings.HasPrefix(info.Name(), "output-") {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Stats{Groups: map[string]*Group{}}
+	for _, path := range files {
+		if err := scanFile(ctx, s, path); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+func scanFile(ctx context.Context, s *Stats, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := s.Read(ctx, f); err != nil {
+		return &os.PathError{Op: "read", Path: path, Err: err}
+	}
+
+	return nil
+}
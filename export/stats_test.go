@@ -0,0 +1,129 @@
+package export_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/royge/gostcalc/export"
+	"github.com/royge/gostcalc/firestore"
+)
+
+func TestScan(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "all_namespaces", "all_kinds")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	short := property("name", 0, false, bytesField(3, []byte("x")))
+	long := property("name", 0, false, bytesField(3, []byte("xxxxxxxxxxxxxxxxxxxx")))
+	extra := property("note", 0, false, bytesField(3, []byte("y")))
+
+	files := map[string][]byte{
+		"output-0": encodeLog(
+			encodeEntity("users/a", short),
+			encodeEntity("users/b", long, extra),
+			encodeEntity("users/a/logs/1", short),
+		),
+		"output-1": encodeLog(
+			encodeEntity("users/c", short),
+			encodeEntity("teams/t/logs/2", short),
+		),
+		"all_kinds.export_metadata": []byte("ignored"),
+	}
+	for name, b := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), b, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s, err := export.Scan(context.Background(), filepath.Dir(filepath.Dir(dir)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := s.Names(); len(got) != 2 || got[0] != "logs" || got[1] != "users" {
+		t.Fatalf("want groups [logs users], got %v", got)
+	}
+
+	users := s.Groups["users"]
+
+	// users/a: name 2 + 6 + 16 = 24, data 32 + 1 + 4 + 2 = 39.
+	small := int64(24 + 39)
+	// users/b: 19 more bytes of name and a 1 + 4 + 2 bytes note field.
+	large := small + 19 + 7
+
+	if users.Count() != 3 {
+		t.Errorf("want 3 users, got %v", users.Count())
+	}
+	if users.Total != 2*small+large {
+		t.Errorf("want total %v, got %v", 2*small+large, users.Total)
+	}
+	if got := users.Percentile(50); got != small {
+		t.Errorf("want P50 %v, got %v", small, got)
+	}
+	if got := users.Percentile(99); got != large {
+		t.Errorf("want P99 %v, got %v", large, got)
+	}
+	if users.Fields["name"] != 3 || users.Fields["note"] != 1 {
+		t.Errorf("want name in 3 and note in 1 users, got %v", users.Fields)
+	}
+
+	if users.Types["name"]["string"] != 3 {
+		t.Errorf("want 3 string names, got %v", users.Types["name"])
+	}
+	if users.IndexEntries != 8 {
+		t.Errorf("want 8 index entries, got %v", users.IndexEntries)
+	}
+
+	if got := s.Groups["logs"].Count(); got != 2 {
+		t.Errorf("want 2 logs, got %v", got)
+	}
+}
+
+func TestValueType(t *testing.T) {
+	tt := []struct {
+		input interface{}
+		want  string
+	}{
+		{nil, "null"},
+		{true, "boolean"},
+		{int64(1), "integer"},
+		{1.5, "double"},
+		{"a", "string"},
+		{[]byte("a"), "bytes"},
+		{time.Now(), "timestamp"},
+		{firestore.GeoPoint{}, "geopoint"},
+		{[]interface{}{}, "array"},
+		{map[string]interface{}{}, "map"},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.want, func(t *testing.T) {
+			if got := export.ValueType(tc.input); got != tc.want {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGroup_Percentile(t *testing.T) {
+	g := &export.Group{Sizes: []int64{300, 100, 200, 400}}
+
+	if want, got := int64(200), g.Percentile(50); want != got {
+		t.Errorf("want Percentile(50) = %v, got %v", want, got)
+	}
+
+	if want, got := int64(400), g.Percentile(99); want != got {
+		t.Errorf("want Percentile(99) = %v, got %v", want, got)
+	}
+
+	if g.Sizes[0] != 300 || g.Sizes[3] != 400 {
+		t.Errorf("want the sizes kept in scan order, got %v", g.Sizes)
+	}
+}
@@ -0,0 +1,123 @@
+package firestore
+
+import (
+	"context"
+	"math/big"
+)
+
+const (
+	// BackupPricePerGB is the backup storage price per GB-month.
+	BackupPricePerGB = 38 * Cent / 10
+
+	// RestorePricePerGB is the price per GB restored from a backup.
+	RestorePricePerGB = 228 * Cent / 10
+
+	// PITRPricePerGB is the point-in-time recovery storage price per
+	// GB-month.
+	PITRPricePerGB = 18 * Cent
+
+	// PITRWindowDays is the number of days of point-in-time recovery data.
+	PITRWindowDays = 7
+)
+
+// Bytes returns the number of bytes stored in the database at the end of the
+// month, before the free tier.
+func (ms *MonthlyStorageCalculator) Bytes(ctx context.Context, count *big.Int) (*big.Int, error) {
+	daily, err := ms.D.Calculate(ctx, count)
+	if err != nil {
+		return nil, err
+	}
+
+	return daily.Mul(daily, big.NewInt(days(ms.Days))), nil
+}
+
+type MonthlyBackupCalculator struct {
+	S *MonthlyStorageCalculator
+
+	// RetentionDays is the number of days every backup is kept.
+	RetentionDays int64
+
+	// IntervalDays is the number of days between backups, 1 for daily and 7
+	// for weekly backups.
+	IntervalDays int64
+
+	// Unit Price.
+	// Price per GB-month.
+	Price Money
+}
+
+// Calculate returns the cost of the backups of the database. Every backup is
+// a full copy, and the retention keeps one backup per interval.
+func (mb *MonthlyBackupCalculator) Calculate(ctx context.Context, count *big.Int) (Money, error) {
+	size, err := mb.S.Bytes(ctx, count)
+	if err != nil {
+		return 0, err
+	}
+
+	stored := size.Mul(size, big.NewInt(mb.Backups()))
+
+	return Charge(stored, mb.Price, OneGB), nil
+}
+
+// Backups returns the number of backups kept at a time.
+func (mb *MonthlyBackupCalculator) Backups() int64 {
+	interval := mb.IntervalDays
+	if interval <= 0 {
+		interval = 1
+	}
+
+	return (mb.RetentionDays + interval - 1) / interval
+}
+
+type MonthlyRestoreCalculator struct {
+	S *MonthlyStorageCalculator
+
+	// Restores is the number of full restores every month.
+	Restores int64
+
+	// Unit Price.
+	// Price per GB restored.
+	Price Money
+}
+
+// Calculate returns the cost of restoring the database.
+func (mr *MonthlyRestoreCalculator) Calculate(ctx context.Context, count *big.Int) (Money, error) {
+	size, err := mr.S.Bytes(ctx, count)
+	if err != nil {
+		return 0, err
+	}
+
+	restored := size.Mul(size, big.NewInt(mr.Restores))
+
+	return Charge(restored, mr.Price, OneGB), nil
+}
+
+type MonthlyPITRCalculator struct {
+	D *DailyStorageCalculator
+
+	// WindowDays is the number of days of retained versions, defaults to
+	// PITRWindowDays.
+	WindowDays int64
+
+	// Unit Price.
+	// Price per GB-month.
+	Price Money
+}
+
+// Calculate returns the cost of the point-in-time recovery data of count
+// daily writes. Every write keeps a version of the document for the window.
+func (mp *MonthlyPITRCalculator) Calculate(ctx context.Context, count *big.Int) (Money, error) {
+	daily, err := mp.D.Calculate(ctx, count)
+	if err != nil {
+		return 0, err
+	}
+
+	window := mp.WindowDays
+	if window <= 0 {
+		window = PITRWindowDays
+	}
+
+	stored := daily.Mul(daily, big.NewInt(window))
+
+	return Charge(stored, mp.Price, OneGB), nil
+}
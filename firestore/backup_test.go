@@ -0,0 +1,90 @@
+package firestore_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+func Test_MonthlyBackupCalculator_Calculate(t *testing.T) {
+	tt := []struct {
+		name      string
+		retention int64
+		interval  int64
+		want      firestore.Money
+	}{
+		// 30GB * 14 daily backups * 0.038
+		{"daily", 14, 1, 1596 * firestore.Cent},
+		// 30GB * 2 weekly backups * 0.038
+		{"weekly", 14, 7, 228 * firestore.Cent},
+		{"partial week", 10, 7, 228 * firestore.Cent},
+		{"no retention", 0, 1, 0},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			calc := &firestore.MonthlyBackupCalculator{
+				S: &firestore.MonthlyStorageCalculator{
+					D: &firestore.DailyStorageCalculator{Size: 1000},
+				},
+				RetentionDays: tc.retention,
+				IntervalDays:  tc.interval,
+				Price:         firestore.BackupPricePerGB,
+			}
+
+			got, err := calc.Calculate(context.Background(), big.NewInt(1000000))
+			if err != nil {
+				t.Fatalf("unable to calculate backup cost: %v", err)
+			}
+
+			if tc.want != got {
+				t.Errorf("want Calculate() result to be %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func Test_MonthlyRestoreCalculator_Calculate(t *testing.T) {
+	calc := &firestore.MonthlyRestoreCalculator{
+		S: &firestore.MonthlyStorageCalculator{
+			D: &firestore.DailyStorageCalculator{Size: 1000},
+		},
+		Restores: 2,
+		Price:    firestore.RestorePricePerGB,
+	}
+
+	// 30GB * 2 restores * 0.228
+	want := 1368 * firestore.Cent
+
+	got, err := calc.Calculate(context.Background(), big.NewInt(1000000))
+	if err != nil {
+		t.Fatalf("unable to calculate restore cost: %v", err)
+	}
+
+	if want != got {
+		t.Errorf("want Calculate() result to be %v, got %v", want, got)
+	}
+}
+
+func Test_MonthlyPITRCalculator_Calculate(t *testing.T) {
+	calc := &firestore.MonthlyPITRCalculator{
+		D:     &firestore.DailyStorageCalculator{Size: 1000},
+		Price: firestore.PITRPricePerGB,
+	}
+
+	// 1GB of daily writes * 7 days * 0.18
+	want := 126 * firestore.Cent
+
+	got, err := calc.Calculate(context.Background(), big.NewInt(1000000))
+	if err != nil {
+		t.Fatalf("unable to calculate PITR cost: %v", err)
+	}
+
+	if want != got {
+		t.Errorf("want Calculate() result to be %v, got %v", want, got)
+	}
+}
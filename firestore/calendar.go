@@ -0,0 +1,94 @@
+package firestore
+
+import (
+	"context"
+	"math/big"
+	"time"
+)
+
+// DailyCalculator prices the operations of a day.
+type DailyCalculator interface {
+	Calculate(context.Context, *big.Int) (Money, error)
+}
+
+// ExactCalculator prices the operations of a day before rounding, in
+// micro-units.
+type ExactCalculator interface {
+	Exact(context.Context, *big.Int) (*big.Rat, error)
+}
+
+// QuotaZone is the time zone of the midnight the daily free quota resets at.
+const QuotaZone = "America/Los_Angeles"
+
+// QuotaLocation returns the location of QuotaZone. Without a time zone
+// database it falls back to Pacific Standard Time, an hour off in summer.
+func QuotaLocation() *time.Location {
+	loc, err := time.LoadLocation(QuotaZone)
+	if err != nil {
+		return time.FixedZone("PST", -8*60*60)
+	}
+
+	return loc
+}
+
+// DaysIn returns the number of days of the calendar month of t, 28 to 31.
+func DaysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// CalendarMonthCalculator prices every day of a calendar month on its own.
+// The daily free quota resets at midnight Pacific Time, so each day has its
+// own free quota instead of one applied to an averaged daily count.
+type CalendarMonthCalculator struct {
+	D ExactCalculator
+
+	// Days scales the daily count for every day of the month, 1 for the
+	// average day.
+	Days []float64
+}
+
+// Count returns the operations of the month for count daily operations.
+func (mc *CalendarMonthCalculator) Count(count *big.Int) *big.Int {
+	total := new(big.Int)
+	for _, f := range mc.Days {
+		total.Add(total, scaled(count, f))
+	}
+
+	return total
+}
+
+// Calculate returns the sum of the exact cost of every day of the month,
+// rounded once.
+func (mc *CalendarMonthCalculator) Calculate(ctx context.Context, count *big.Int) (Money, error) {
+	monthly := new(big.Rat)
+	for _, f := range mc.Days {
+		daily, err := mc.D.Exact(ctx, scaled(count, f))
+		if err != nil {
+			return 0, err
+		}
+
+		monthly.Add(monthly, daily)
+	}
+
+	return Bill(monthly), nil
+}
+
+// Return the number of days of a month, MonthNumOfDays when n is zero.
+func days(n int64) int64 {
+	if n <= 0 {
+		return MonthNumOfDays
+	}
+
+	return n
+}
+
+// Return n multiplied by ratio, rounded to the nearest integer.
+func scaled(n *big.Int, ratio float64) *big.Int {
+	r := new(big.Rat).SetFloat64(ratio)
+	if r == nil {
+		return new(big.Int)
+	}
+	r.Mul(r, new(big.Rat).SetInt(n))
+
+	return round(r.Num(), r.Denom(), RoundHalfUp)
+}
@@ -0,0 +1,97 @@
+package firestore_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+func Test_DaysIn(t *testing.T) {
+	tt := []struct {
+		month string
+		want  int
+	}{
+		{"2026-01", 31},
+		{"2026-02", 28},
+		{"2028-02", 29},
+		{"2026-04", 30},
+		{"2026-12", 31},
+	}
+
+	for _, tc := range tt {
+		month, err := time.Parse("2006-01", tc.month)
+		if err != nil {
+			t.Fatalf("unable to parse month: %v", err)
+		}
+
+		if got := firestore.DaysIn(month); tc.want != got {
+			t.Errorf("want %s to have %d days, got %d", tc.month, tc.want, got)
+		}
+	}
+}
+
+func Test_CalendarMonthCalculator_Calculate(t *testing.T) {
+	tt := []struct {
+		name  string
+		days  []float64
+		count int64
+		want  firestore.Money
+	}{
+		// (100,000 - 50,000) / 100,000 * 0.06 * 28
+		{"flat", flat(28), 100000, 84 * firestore.Cent},
+		// The busy day is billed (180,000 - 50,000) / 100,000 * 0.06 = 0.078
+		// rounded to the cent, the quiet day is within the free reads.
+		{"busy and quiet", []float64{1.8, 0.2}, 100000, 8 * firestore.Cent},
+		{"no reads", flat(31), 0, 0},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			calc := &firestore.CalendarMonthCalculator{
+				D:    &firestore.DailyReadCalculator{Price: firestore.ReadUnitPrice},
+				Days: tc.days,
+			}
+
+			got, err := calc.Calculate(context.Background(), big.NewInt(tc.count))
+			if err != nil {
+				t.Fatalf("unable to calculate reads: %v", err)
+			}
+
+			if tc.want != got {
+				t.Errorf("want Calculate() result to be %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func Test_CalendarMonthCalculator_Count(t *testing.T) {
+	calc := &firestore.CalendarMonthCalculator{Days: []float64{1.5, 0.5, 1}}
+
+	if want, got := big.NewInt(3000), calc.Count(big.NewInt(1000)); want.Cmp(got) != 0 {
+		t.Errorf("want %v operations, got %v", want, got)
+	}
+}
+
+// Return the traffic of days average days.
+func flat(days int) []float64 {
+	f := make([]float64, days)
+	for i := range f {
+		f[i] = 1
+	}
+
+	return f
+}
+
+func TestQuotaLocation(t *testing.T) {
+	// Midnight Pacific Standard Time is 08:00 UTC.
+	midnight := time.Date(2026, time.January, 15, 0, 0, 0, 0, firestore.QuotaLocation())
+
+	if want, got := 8, midnight.UTC().Hour(); want != got {
+		t.Errorf("want midnight at %d:00 UTC, got %d:00", want, got)
+	}
+}
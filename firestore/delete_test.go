@@ -9,17 +9,15 @@ import (
 )
 
 func Test_DailyDeleteCalculator_Calculate(t *testing.T) {
-	calc := &firestore.DailyDeleteCalculator{}
-	want := 0.02
+	calc := &firestore.DailyDeleteCalculator{Price: firestore.DeleteUnitPrice}
+	want := 2 * firestore.Cent
 
 	dailyDeletes := big.NewInt(100000)
-	res, err := calc.Calculate(context.Background(), dailyDeletes)
+	got, err := calc.Calculate(context.Background(), dailyDeletes)
 	if err != nil {
 		t.Fatalf("unable to calculate daily deletes: %v", err)
 	}
 
-	got, _ := res.Float64()
-
 	if want != got {
 		t.Errorf("want Calculate() result to be %v, got %v", want, got)
 	}
@@ -27,18 +25,32 @@ func Test_DailyDeleteCalculator_Calculate(t *testing.T) {
 
 func Test_MonthlyDeleteCalculator_Calculate(t *testing.T) {
 	calc := &firestore.MonthlyDeleteCalculator{
-		D: &firestore.DailyDeleteCalculator{},
+		D: &firestore.DailyDeleteCalculator{Price: firestore.DeleteUnitPrice},
 	}
-	// 0.02 * 30
-	want := 0.6
+	// (100,000 - 20,000) / 100,000 * 0.02 * 30 = 0.48, rounded once for
+	// the month.
+	want := 48 * firestore.Cent
 
 	dailyDeletes := big.NewInt(100000)
-	res, err := calc.Calculate(context.Background(), dailyDeletes)
+	got, err := calc.Calculate(context.Background(), dailyDeletes)
 	if err != nil {
 		t.Fatalf("unable to calculate daily deletes: %v", err)
 	}
 
-	got, _ := res.Float64()
+	if want != got {
+		t.Errorf("want Calculate() result to be %v, got %v", want, got)
+	}
+}
+
+func Test_DailyDeleteCalculator_Calculate_NoFreeTier(t *testing.T) {
+	calc := &firestore.DailyDeleteCalculator{Price: firestore.DeleteUnitPrice, NoFreeTier: true}
+	want := 2 * firestore.Cent
+
+	dailyDeletes := big.NewInt(100000)
+	got, err := calc.Calculate(context.Background(), dailyDeletes)
+	if err != nil {
+		t.Fatalf("unable to calculate daily deletes: %v", err)
+	}
 
 	if want != got {
 		t.Errorf("want Calculate() result to be %v, got %v", want, got)
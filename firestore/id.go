@@ -0,0 +1,96 @@
+package firestore
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IDStrategy is how document IDs are generated: one of the named strategies
+// or a decimal length for custom string IDs.
+type IDStrategy string
+
+const (
+	// IDAuto is a 20 character ID generated by Firestore.
+	IDAuto IDStrategy = "auto"
+
+	// IDUUID is a 36 character UUID string.
+	IDUUID IDStrategy = "uuid"
+
+	// IDULID is a 26 character ULID string.
+	IDULID IDStrategy = "ulid"
+
+	// IDNumeric is an integer ID, stored in 8 bytes.
+	IDNumeric IDStrategy = "numeric"
+)
+
+// IDStrategies lists the named strategies in report order.
+var IDStrategies = []IDStrategy{IDAuto, IDUUID, IDULID, IDNumeric}
+
+// Lengths of the string IDs of the named strategies.
+var idLengths = map[IDStrategy]int{
+	IDAuto: 20,
+	IDUUID: 36,
+	IDULID: 26,
+}
+
+// ParseIDStrategy parses a named strategy or a custom ID length.
+func ParseIDStrategy(s string) (IDStrategy, error) {
+	st := IDStrategy(strings.ToLower(strings.TrimSpace(s)))
+	if st == IDNumeric || idLengths[st] > 0 {
+		return st, nil
+	}
+
+	if n, err := strconv.Atoi(string(st)); err == nil && n > 0 {
+		return st, nil
+	}
+
+	return "", fmt.Errorf("unknown ID strategy %q", s)
+}
+
+// SampleID returns an ID of the size of the strategy IDs. Numeric IDs use the
+// __id<number>__ form of integer IDs.
+func (s IDStrategy) SampleID() string {
+	if s == IDNumeric {
+		return "__id1234567890__"
+	}
+
+	n, ok := idLengths[s]
+	if !ok {
+		n, _ = strconv.Atoi(string(s))
+	}
+
+	return strings.Repeat("x", n)
+}
+
+// Size returns the stored size of the strategy IDs in bytes.
+func (s IDStrategy) Size() int64 {
+	return idSize(s.SampleID())
+}
+
+// Return the stored size of a document or collection ID: 8 bytes for integer
+// IDs and the string size otherwise.
+func idSize(id string) int64 {
+	if n := strings.TrimSuffix(strings.TrimPrefix(id, "__id"), "__"); len(n)+6 == len(id) {
+		if _, err := strconv.ParseUint(n, 10, 63); err == nil {
+			return 8
+		}
+	}
+
+	return int64(len(id) + 1)
+}
+
+// WithIDs returns a copy of d with its ID and the document IDs of its
+// collection path generated by s.
+func (d *Document) WithIDs(s IDStrategy) *Document {
+	c := *d
+	c.ID = s.SampleID()
+
+	parts := strings.Split(d.Collection, "/")
+	for i := 1; i < len(parts); i += 2 {
+		parts[i] = s.SampleID()
+	}
+	c.Collection = strings.Join(parts, "/")
+
+	return &c
+}
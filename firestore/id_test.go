@@ -0,0 +1,84 @@
+package firestore_test
+
+import (
+	"testing"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+func TestParseIDStrategy(t *testing.T) {
+	tt := []struct {
+		input string
+		want  firestore.IDStrategy
+		size  int64
+	}{
+		{"auto", firestore.IDAuto, 21},
+		{"UUID", firestore.IDUUID, 37},
+		{"ulid", firestore.IDULID, 27},
+		{"numeric", firestore.IDNumeric, 8},
+		{"12", "12", 13},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := firestore.ParseIDStrategy(tc.input)
+			if err != nil {
+				t.Fatalf("unable to parse strategy: %v", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+
+			if size := got.Size(); size != tc.size {
+				t.Errorf("want size %v, got %v", tc.size, size)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "guid", "0", "-1"} {
+		if _, err := firestore.ParseIDStrategy(input); err == nil {
+			t.Errorf("%q: want error, got nil", input)
+		}
+	}
+}
+
+func TestDocument_WithIDs(t *testing.T) {
+	doc := &firestore.Document{
+		ID:         "a",
+		Collection: "profiles/jeff/logs",
+		CompositeIndexes: []map[string]interface{}{
+			{"type": 1},
+		},
+	}
+
+	tt := []struct {
+		id   firestore.IDStrategy
+		name int64
+	}{
+		// profiles, logs, two IDs and the 16 bytes of padding.
+		{firestore.IDAuto, 9 + 5 + 21 + 21 + 16},
+		{firestore.IDUUID, 9 + 5 + 37 + 37 + 16},
+		{firestore.IDNumeric, 9 + 5 + 8 + 8 + 16},
+	}
+
+	for _, tc := range tt {
+		got := doc.WithIDs(tc.id).Breakdown()
+
+		if got.Name != tc.name {
+			t.Errorf("%v: want name size %v, got %v", tc.id, tc.name, got.Name)
+		}
+
+		// The index entry holds the name, the parent name and the value.
+		parent := tc.name - tc.id.Size() - 5
+		if want := tc.name + parent + 32 + 8; got.CompositeIndexes != want {
+			t.Errorf("%v: want index size %v, got %v", tc.id, want, got.CompositeIndexes)
+		}
+	}
+
+	if doc.ID != "a" || doc.Collection != "profiles/jeff/logs" {
+		t.Errorf("want the document unchanged, got %v/%v", doc.Collection, doc.ID)
+	}
+}
@@ -0,0 +1,149 @@
+package firestore
+
+import (
+	"context"
+	"math/big"
+)
+
+const (
+	// ExportStoragePricePerGB is the Cloud Storage price per GB-month of
+	// export files.
+	ExportStoragePricePerGB = 26 * Cent / 10
+)
+
+// Return the number of times something every interval days happens in a
+// month.
+func monthly(interval int64) int64 {
+	if interval <= 0 {
+		interval = 1
+	}
+
+	return (MonthNumOfDays + interval - 1) / interval
+}
+
+type MonthlyExportCalculator struct {
+	D *DailyReadCalculator
+
+	// IntervalDays is the number of days between exports, 1 for daily and 7
+	// for weekly exports.
+	IntervalDays int64
+
+	// DailyReads is the number of other document reads of every day, which
+	// use the free reads first.
+	DailyReads *big.Int
+}
+
+// Exports returns the number of exports every month.
+func (me *MonthlyExportCalculator) Exports() int64 {
+	return monthly(me.IntervalDays)
+}
+
+// Reads returns the number of document reads of exporting count documents
+// every month.
+func (me *MonthlyExportCalculator) Reads(count *big.Int) *big.Int {
+	return new(big.Int).Mul(count, big.NewInt(me.Exports()))
+}
+
+// Calculate returns the cost of the document reads of exporting count
+// documents on every export of the month.
+func (me *MonthlyExportCalculator) Calculate(ctx context.Context, count *big.Int) (Money, error) {
+	cost, err := extra(ctx, me.D, me.DailyReads, count)
+	if err != nil {
+		return 0, err
+	}
+
+	return Bill(cost.Mul(cost, big.NewRat(me.Exports(), 1))), nil
+}
+
+type MonthlyImportCalculator struct {
+	D *DailyWriteCalculator
+
+	// Imports is the number of imports every month.
+	Imports int64
+
+	// DailyWrites is the number of other document writes of every day, which
+	// use the free writes first.
+	DailyWrites *big.Int
+}
+
+// Writes returns the number of document writes of importing count documents
+// every month.
+func (mi *MonthlyImportCalculator) Writes(count *big.Int) *big.Int {
+	return new(big.Int).Mul(count, big.NewInt(mi.Imports))
+}
+
+// Calculate returns the cost of the document writes of importing count
+// documents on every import of the month.
+func (mi *MonthlyImportCalculator) Calculate(ctx context.Context, count *big.Int) (Money, error) {
+	cost, err := extra(ctx, mi.D, mi.DailyWrites, count)
+	if err != nil {
+		return 0, err
+	}
+
+	return Bill(cost.Mul(cost, big.NewRat(mi.Imports, 1))), nil
+}
+
+// Return the exact daily cost of count operations on top of other
+// operations.
+func extra(ctx context.Context, d ExactCalculator, other, count *big.Int) (*big.Rat, error) {
+	if other == nil {
+		other = new(big.Int)
+	}
+
+	without, err := d.Exact(ctx, other)
+	if err != nil {
+		return nil, err
+	}
+
+	with, err := d.Exact(ctx, new(big.Int).Add(other, count))
+	if err != nil {
+		return nil, err
+	}
+
+	return with.Sub(with, without), nil
+}
+
+type MonthlyExportStorageCalculator struct {
+	// Document exported.
+	Document *Document
+
+	// Size overrides the exported document size in bytes when set.
+	Size int64
+
+	// RetentionDays is the number of days every export is kept.
+	RetentionDays int64
+
+	// IntervalDays is the number of days between exports.
+	IntervalDays int64
+
+	// Unit Price.
+	// Price per GB-month.
+	Price Money
+}
+
+// Generations returns the number of exports kept at a time.
+func (ms *MonthlyExportStorageCalculator) Generations() int64 {
+	return (&MonthlyBackupCalculator{
+		RetentionDays: ms.RetentionDays,
+		IntervalDays:  ms.IntervalDays,
+	}).Backups()
+}
+
+// Bytes returns the size of the kept exports of count documents. Exports hold
+// the document names and data, but no index entries.
+func (ms *MonthlyExportStorageCalculator) Bytes(count *big.Int) *big.Int {
+	size := big.NewInt(ms.Size)
+	if ms.Size == 0 {
+		size.SetInt64(ms.Document.nameSize() + ms.Document.dataSize())
+	}
+
+	size.Mul(size, count)
+
+	return size.Mul(size, big.NewInt(ms.Generations()))
+}
+
+// Calculate returns the Cloud Storage cost of the kept exports of count
+// documents.
+func (ms *MonthlyExportStorageCalculator) Calculate(_ context.Context, count *big.Int) (Money, error) {
+	return Charge(ms.Bytes(count), ms.Price, OneGB), nil
+}
@@ -0,0 +1,114 @@
+package firestore_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+func Test_MonthlyExportCalculator_Calculate(t *testing.T) {
+	tt := []struct {
+		name     string
+		interval int64
+		reads    int64
+		want     firestore.Money
+	}{
+		// (10,000,000 - 50,000) / 100,000 * 0.06 * 30
+		{"daily", 1, 0, 17910 * firestore.Cent},
+		// 10,000,000 / 100,000 * 0.06 * 30, other reads use the free tier.
+		{"daily with reads", 1, 1000000, 18000 * firestore.Cent},
+		// 10,000,000 / 100,000 * 0.06 * 5
+		{"weekly with reads", 7, 1000000, 3000 * firestore.Cent},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			calc := &firestore.MonthlyExportCalculator{
+				D:            &firestore.DailyReadCalculator{Price: firestore.ReadUnitPrice},
+				IntervalDays: tc.interval,
+				DailyReads:   big.NewInt(tc.reads),
+			}
+
+			got, err := calc.Calculate(context.Background(), big.NewInt(10000000))
+			if err != nil {
+				t.Fatalf("unable to calculate export cost: %v", err)
+			}
+
+			if tc.want != got {
+				t.Errorf("want Calculate() result to be %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func Test_MonthlyExportCalculator_Reads(t *testing.T) {
+	calc := &firestore.MonthlyExportCalculator{IntervalDays: 7}
+
+	if want, got := big.NewInt(50), calc.Reads(big.NewInt(10)); want.Cmp(got) != 0 {
+		t.Errorf("want %v reads, got %v", want, got)
+	}
+}
+
+func Test_MonthlyImportCalculator_Calculate(t *testing.T) {
+	calc := &firestore.MonthlyImportCalculator{
+		D:       &firestore.DailyWriteCalculator{Price: firestore.WriteUnitPrice},
+		Imports: 2,
+	}
+
+	// (10,000,000 - 20,000) / 100,000 * 0.18 = 17.964, twice.
+	want := 3593 * firestore.Cent
+
+	got, err := calc.Calculate(context.Background(), big.NewInt(10000000))
+	if err != nil {
+		t.Fatalf("unable to calculate import cost: %v", err)
+	}
+
+	if want != got {
+		t.Errorf("want Calculate() result to be %v, got %v", want, got)
+	}
+
+	if w := big.NewInt(20000000); w.Cmp(calc.Writes(big.NewInt(10000000))) != 0 {
+		t.Errorf("want %v writes", w)
+	}
+}
+
+func Test_MonthlyExportStorageCalculator_Calculate(t *testing.T) {
+	calc := &firestore.MonthlyExportStorageCalculator{
+		Size:          1000,
+		RetentionDays: 7,
+		IntervalDays:  1,
+		Price:         firestore.ExportStoragePricePerGB,
+	}
+
+	// 10GB * 7 exports * 0.026
+	want := 182 * firestore.Cent
+
+	got, err := calc.Calculate(context.Background(), big.NewInt(10000000))
+	if err != nil {
+		t.Fatalf("unable to calculate export storage cost: %v", err)
+	}
+
+	if want != got {
+		t.Errorf("want Calculate() result to be %v, got %v", want, got)
+	}
+
+	// Exports leave out the index entries of the document.
+	doc := &firestore.Document{
+		ID:         "a",
+		Collection: "users",
+		Data:       map[string]interface{}{"type": 1},
+		CompositeIndexes: []map[string]interface{}{
+			{"type": 1},
+		},
+	}
+	calc = &firestore.MonthlyExportStorageCalculator{Document: doc, RetentionDays: 1}
+
+	b := doc.Breakdown()
+	if want, got := big.NewInt(b.Name+b.Data), calc.Bytes(big.NewInt(1)); want.Cmp(got) != 0 {
+		t.Errorf("want %v bytes, got %v", want, got)
+	}
+}
@@ -0,0 +1,188 @@
+package firestore
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Money is an exact amount of currency in micro-units (1/1,000,000 of a
+// unit).
+type Money int64
+
+const (
+	// Micro is the smallest amount Money can represent.
+	Micro Money = 1
+
+	// Cent is one hundredth of a currency unit.
+	Cent Money = 10000
+
+	// Dollar is one currency unit.
+	Dollar Money = 1000000
+)
+
+// RoundingMode defines how an amount is rounded to a coarser unit.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest unit, ties away from zero.
+	RoundHalfUp RoundingMode = iota
+
+	// RoundHalfEven rounds to the nearest unit, ties to the even unit.
+	RoundHalfEven
+
+	// RoundDown rounds towards zero.
+	RoundDown
+
+	// RoundUp rounds away from zero.
+	RoundUp
+)
+
+const (
+	// BillingUnit is the unit every billed SKU line is rounded to.
+	BillingUnit = Cent
+
+	// BillingRounding is the rounding mode used for billed SKU lines.
+	BillingRounding = RoundHalfUp
+)
+
+// Round rounds m to a multiple of unit using mode.
+func (m Money) Round(unit Money, mode RoundingMode) Money {
+	q := round(big.NewInt(int64(m)), big.NewInt(int64(unit)), mode)
+
+	return Money(q.Int64()) * unit
+}
+
+// Float64 returns the nearest float64 value of m in currency units.
+func (m Money) Float64() float64 {
+	return float64(m) / float64(Dollar)
+}
+
+// String formats m in currency units with at least two decimal places.
+func (m Money) String() string {
+	sign := ""
+	v := int64(m)
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+
+	units, micros := v/int64(Dollar), v%int64(Dollar)
+
+	frac := fmt.Sprintf("%06d", micros)
+	for len(frac) > 2 && frac[len(frac)-1] == '0' {
+		frac = frac[:len(frac)-1]
+	}
+
+	return fmt.Sprintf("%s%d.%s", sign, units, frac)
+}
+
+// MarshalJSON encodes m as an exact decimal string.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON decodes m from a decimal string or number.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		s = string(b)
+	}
+
+	v, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+
+	*m = v
+
+	return nil
+}
+
+// Charge returns the billed amount for quantity billed at price per every
+// per units of quantity, rounded to BillingUnit. Negative quantities are
+// never billed.
+func Charge(quantity *big.Int, price Money, per int64) Money {
+	return Bill(Amount(quantity, price, per))
+}
+
+// Amount returns the exact amount in micro-units of quantity billed at price
+// per every per units of quantity, before rounding. Negative quantities are
+// never billed.
+func Amount(quantity *big.Int, price Money, per int64) *big.Rat {
+	if quantity.Sign() <= 0 {
+		return new(big.Rat)
+	}
+
+	n := new(big.Int).Mul(quantity, big.NewInt(int64(price)))
+
+	return new(big.Rat).SetFrac(n, big.NewInt(per))
+}
+
+// Bill rounds an exact amount in micro-units to BillingUnit, once per
+// invoice line. Amounts beyond the range of Money saturate to the largest or
+// smallest multiple of BillingUnit instead of wrapping around.
+func Bill(amount *big.Rat) Money {
+	d := new(big.Int).Mul(amount.Denom(), big.NewInt(int64(BillingUnit)))
+
+	q := round(amount.Num(), d, BillingRounding)
+
+	limit := big.NewInt(math.MaxInt64 / int64(BillingUnit))
+	switch {
+	case q.Cmp(limit) > 0:
+		q = limit
+	case q.Cmp(new(big.Int).Neg(limit)) < 0:
+		q = limit.Neg(limit)
+	}
+
+	return Money(q.Int64()) * BillingUnit
+}
+
+// Divide n by d and round the quotient using mode.
+func round(n, d *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	// Direction away from zero.
+	away := int64(n.Sign() * d.Sign())
+
+	switch mode {
+	case RoundDown:
+		return q
+	case RoundUp:
+		return q.Add(q, big.NewInt(away))
+	}
+
+	// Compare twice the remainder to the divisor.
+	r2 := new(big.Int).Abs(r)
+	r2.Lsh(r2, 1)
+	cmp := r2.Cmp(new(big.Int).Abs(d))
+
+	if cmp > 0 || (cmp == 0 && (mode == RoundHalfUp || q.Bit(0) == 1)) {
+		q.Add(q, big.NewInt(away))
+	}
+
+	return q
+}
+
+// ParseMoney parses a decimal amount such as "0.18" into Money. Digits beyond
+// the micro-unit are rounded using BillingRounding.
+func ParseMoney(s string) (Money, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+
+	return RatMoney(r), nil
+}
+
+// RatMoney converts an exact rational amount into Money rounded to the
+// micro-unit using BillingRounding.
+func RatMoney(r *big.Rat) Money {
+	n := new(big.Int).Mul(r.Num(), big.NewInt(int64(Dollar)))
+
+	return Money(round(n, r.Denom(), BillingRounding).Int64())
+}
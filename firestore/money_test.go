@@ -0,0 +1,137 @@
+package firestore_test
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+func TestMoney_Round(t *testing.T) {
+	tt := []struct {
+		name  string
+		input firestore.Money
+		mode  firestore.RoundingMode
+		want  firestore.Money
+	}{
+		{"half up below half", 144000, firestore.RoundHalfUp, 140000},
+		{"half up at half", 145000, firestore.RoundHalfUp, 150000},
+		{"half up negative", -145000, firestore.RoundHalfUp, -150000},
+		{"half even at half to even", 145000, firestore.RoundHalfEven, 140000},
+		{"half even at half to odd", 155000, firestore.RoundHalfEven, 160000},
+		{"down", 149999, firestore.RoundDown, 140000},
+		{"up", 140001, firestore.RoundUp, 150000},
+		{"exact", 140000, firestore.RoundUp, 140000},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.input.Round(firestore.Cent, tc.mode)
+			if tc.want != got {
+				t.Errorf("want Round() = %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	tt := []struct {
+		input firestore.Money
+		want  string
+	}{
+		{0, "0.00"},
+		{14 * firestore.Cent, "0.14"},
+		{420 * firestore.Cent, "4.20"},
+		{144000, "0.144"},
+		{firestore.Micro, "0.000001"},
+		{-5 * firestore.Cent, "-0.05"},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.want, func(t *testing.T) {
+			got := tc.input.String()
+			if tc.want != got {
+				t.Errorf("want String() = %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCharge(t *testing.T) {
+	tt := []struct {
+		name     string
+		quantity int64
+		price    firestore.Money
+		per      int64
+		want     firestore.Money
+	}{
+		{"rounds to the cent", 80000, firestore.WriteUnitPrice, firestore.Unit, 14 * firestore.Cent},
+		{"rounds half up", 25000, firestore.DeleteUnitPrice, firestore.Unit, 1 * firestore.Cent},
+		{"no charge within free tier", -1000, firestore.ReadUnitPrice, firestore.Unit, 0},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			got := firestore.Charge(big.NewInt(tc.quantity), tc.price, tc.per)
+			if tc.want != got {
+				t.Errorf("want Charge() = %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	b, err := json.Marshal(firestore.PriceList{Write: firestore.WriteUnitPrice})
+	if err != nil {
+		t.Fatalf("unable to marshal prices: %v", err)
+	}
+
+	want := `{"write":"0.18","read":"0.00","delete":"0.00","storage":"0.00","ingress":"0.00"}`
+	if got := string(b); want != got {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	var m struct{ A, B firestore.Money }
+	if err := json.Unmarshal([]byte(`{"A": "0.144", "B": 12.5}`), &m); err != nil {
+		t.Fatalf("unable to unmarshal money: %v", err)
+	}
+
+	if m.A != 144000 || m.B != 12500000 {
+		t.Errorf("want 0.144 and 12.50, got %v and %v", m.A, m.B)
+	}
+}
+
+func TestBill(t *testing.T) {
+	// 0.144 a day for 30 days is one invoice line of 4.32.
+	daily := firestore.Amount(big.NewInt(80000), firestore.WriteUnitPrice, firestore.Unit)
+	monthly := new(big.Rat).Mul(daily, big.NewRat(firestore.MonthNumOfDays, 1))
+
+	if want, got := 432*firestore.Cent, firestore.Bill(monthly); want != got {
+		t.Errorf("want Bill() = %v, got %v", want, got)
+	}
+
+	if got := firestore.Amount(big.NewInt(-1), firestore.WriteUnitPrice, firestore.Unit); got.Sign() != 0 {
+		t.Errorf("want no amount for a negative quantity, got %v", got)
+	}
+}
+
+func TestBill_Overflow(t *testing.T) {
+	largest := firestore.Money(math.MaxInt64) / firestore.BillingUnit * firestore.BillingUnit
+
+	huge := new(big.Rat).SetFrac(new(big.Int).Lsh(big.NewInt(1), 80), big.NewInt(1))
+	if got := firestore.Bill(huge); got != largest {
+		t.Errorf("want Bill() = %v, got %v", largest, got)
+	}
+
+	if got := firestore.Bill(new(big.Rat).Neg(huge)); got != -largest {
+		t.Errorf("want Bill() = %v, got %v", -largest, got)
+	}
+}
@@ -10,21 +10,28 @@ const (
 	MonthlyFreeIngress = 10737418240
 
 	// IngressPricePerGB is ingress price per GB after free.
-	IngressPricePerGB = 0.12
+	IngressPricePerGB = 12 * Cent
 )
 
 type DailyNetworkingCalculator struct {
 	// Document in transit.
 	Document []byte
+
+	// Size overrides the document size in bytes when set.
+	Size int64
 }
 
-func (dn *DailyNetworkingCalculator) Calculate(_ context.Context, count *big.Int) (*big.Float, error) {
+// Calculate returns the number of bytes transferred daily.
+func (dn *DailyNetworkingCalculator) Calculate(_ context.Context, count *big.Int) (*big.Int, error) {
 	// Get document size in bytes.
-	size := big.NewInt(int64(len(dn.Document)))
+	size := big.NewInt(dn.Size)
+	if dn.Size == 0 {
+		size.SetInt64(int64(len(dn.Document)))
+	}
 
 	daily := size.Mul(size, count)
 
-	return new(big.Float).SetInt(daily), nil
+	return daily, nil
 }
 
 type MonthlyNetworkingCalculator struct {
@@ -32,24 +39,31 @@ type MonthlyNetworkingCalculator struct {
 
 	// Unit Price.
 	// Price per GB.
-	Price float64
+	Price Money
+
+	// NoFreeTier bills all the transferred data, for databases without the
+	// free quota.
+	NoFreeTier bool
+
+	// Days is the number of days of the month, defaults to MonthNumOfDays.
+	Days int64
 }
 
-func (mn *MonthlyNetworkingCalculator) Calculate(ctx context.Context, count *big.Int) (*big.Float, error) {
+func (mn *MonthlyNetworkingCalculator) Calculate(ctx context.Context, count *big.Int) (Money, error) {
 	daily, err := mn.D.Calculate(ctx, count)
 	if err != nil {
-		return new(big.Float), err
+		return 0, err
 	}
 
-	days := new(big.Float).SetInt64(MonthNumOfDays)
-	monthly := daily.Mul(daily, days)
+	monthly := daily.Mul(daily, big.NewInt(days(mn.Days)))
 
-	free := new(big.Float).SetInt64(MonthlyFreeIngress)
+	if !mn.NoFreeTier {
+		free := big.NewInt(MonthlyFreeIngress)
 
-	monthly = monthly.Sub(monthly, free)
-	monthly = monthly.Quo(monthly, new(big.Float).SetInt64(OneGB))
+		monthly = monthly.Sub(monthly, free)
+	}
 
-	cost := monthly.Mul(monthly, new(big.Float).SetFloat64(mn.Price))
+	cost := Charge(monthly, mn.Price, OneGB)
 
 	return cost, nil
 }
@@ -3,7 +3,6 @@ package firestore_test
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"math/big"
 	"testing"
 
@@ -45,7 +44,30 @@ func Test_MonthlyNetworkingCalculator_Calculate(t *testing.T) {
 		t.Fatalf("unable to calculate ingress cost: %v", err)
 	}
 
-	fmt.Println("Networking Ingress Cost: $", cost)
+	// Document: {"id":"<36>","merchant_id":"<36>","profile_id":"<36>"}
+	// = 150 bytes
+	//
+	// 150 * 10,000,000 * 30 = 45,000,000,000 bytes
+	// (45,000,000,000 - 10,737,418,240) / 1,000,000,000 * 0.12 = 4.1115...
+	want := 411 * firestore.Cent
 
-	t.Error("TODO!")
+	if want != cost {
+		t.Errorf("want Calculate() result to be %v, got %v", want, cost)
+	}
+}
+
+func Test_DailyNetworkingCalculator_Size(t *testing.T) {
+	calc := &firestore.DailyNetworkingCalculator{
+		Document: []byte(`{"id":"1"}`),
+		Size:     1024,
+	}
+
+	got, err := calc.Calculate(context.Background(), big.NewInt(10))
+	if err != nil {
+		t.Fatalf("unable to calculate ingress: %v", err)
+	}
+
+	if want := big.NewInt(10240); want.Cmp(got) != 0 {
+		t.Errorf("want Calculate() result to be %v, got %v", want, got)
+	}
 }
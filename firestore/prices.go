@@ -0,0 +1,154 @@
+package firestore
+
+import "fmt"
+
+// PriceList holds the unit prices of the billed Firestore SKUs.
+type PriceList struct {
+	// Write is the price per Unit of document writes.
+	Write Money `json:"write"`
+
+	// Read is the price per Unit of document reads.
+	Read Money `json:"read"`
+
+	// Delete is the price per Unit of document deletes.
+	Delete Money `json:"delete"`
+
+	// Storage is the price per GB of stored data.
+	Storage Money `json:"storage"`
+
+	// Ingress is the price per GB of network ingress.
+	Ingress Money `json:"ingress"`
+
+	// Backup is the price per GB-month of backup storage.
+	Backup Money `json:"backup,omitempty"`
+
+	// Restore is the price per GB restored from a backup.
+	Restore Money `json:"restore,omitempty"`
+
+	// PITR is the price per GB-month of point-in-time recovery data.
+	PITR Money `json:"pitr,omitempty"`
+
+	// ExportStorage is the Cloud Storage price per GB-month of exports.
+	ExportStorage Money `json:"export_storage,omitempty"`
+}
+
+// DefaultPrices is the USD list price of every SKU.
+var DefaultPrices = PriceList{
+	Write:   WriteUnitPrice,
+	Read:    ReadUnitPrice,
+	Delete:  DeleteUnitPrice,
+	Storage: PricePerGB,
+	Ingress: IngressPricePerGB,
+	Backup:  BackupPricePerGB,
+	Restore: RestorePricePerGB,
+	PITR:    PITRPricePerGB,
+
+	ExportStorage: ExportStoragePricePerGB,
+}
+
+// ParsePrice parses a unit price such as "0.18". Zero makes a SKU free, and
+// negative prices are rejected.
+func ParsePrice(s string) (Money, error) {
+	m, err := ParseMoney(s)
+	if err != nil {
+		return 0, err
+	}
+
+	if m < 0 {
+		return 0, fmt.Errorf("negative price %q", s)
+	}
+
+	return m, nil
+}
+
+// PriceOverrides replaces some unit prices of a PriceList. Prices that are
+// not set are nil, and zero prices make a SKU free.
+type PriceOverrides struct {
+	Write   *Money `json:"write,omitempty"`
+	Read    *Money `json:"read,omitempty"`
+	Delete  *Money `json:"delete,omitempty"`
+	Storage *Money `json:"storage,omitempty"`
+	Ingress *Money `json:"ingress,omitempty"`
+	Backup  *Money `json:"backup,omitempty"`
+	Restore *Money `json:"restore,omitempty"`
+	PITR    *Money `json:"pitr,omitempty"`
+
+	ExportStorage *Money `json:"export_storage,omitempty"`
+}
+
+// Validate rejects negative prices.
+func (o *PriceOverrides) Validate() error {
+	for _, price := range o.prices() {
+		if *price != nil && **price < 0 {
+			return fmt.Errorf("negative price %v", **price)
+		}
+	}
+
+	return nil
+}
+
+// Apply returns p with the prices set in o, p when o is nil.
+func (o *PriceOverrides) Apply(p PriceList) PriceList {
+	list := []*Money{
+		&p.Write, &p.Read, &p.Delete, &p.Storage, &p.Ingress,
+		&p.Backup, &p.Restore, &p.PITR, &p.ExportStorage,
+	}
+
+	for i, price := range o.prices() {
+		if *price != nil {
+			*list[i] = **price
+		}
+	}
+
+	return p
+}
+
+// Merge returns a copy of o with the prices set in by replacing its own.
+// Either one may be nil.
+func (o *PriceOverrides) Merge(by *PriceOverrides) *PriceOverrides {
+	m := &PriceOverrides{}
+	if o != nil {
+		*m = *o
+	}
+
+	merged := m.prices()
+	for i, price := range by.prices() {
+		if *price != nil {
+			*merged[i] = *price
+		}
+	}
+
+	return m
+}
+
+// Map returns a copy of o with f applied to every price that is set, nil
+// when o is nil.
+func (o *PriceOverrides) Map(f func(Money) Money) *PriceOverrides {
+	if o == nil {
+		return nil
+	}
+
+	m := &PriceOverrides{}
+	mapped := m.prices()
+	for i, price := range o.prices() {
+		if *price != nil {
+			v := f(**price)
+			*mapped[i] = &v
+		}
+	}
+
+	return m
+}
+
+// Return the prices of every SKU of o in the order of PriceList, none when o
+// is nil.
+func (o *PriceOverrides) prices() []**Money {
+	if o == nil {
+		return nil
+	}
+
+	return []**Money{
+		&o.Write, &o.Read, &o.Delete, &o.Storage, &o.Ingress,
+		&o.Backup, &o.Restore, &o.PITR, &o.ExportStorage,
+	}
+}
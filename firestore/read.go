@@ -6,39 +6,54 @@ import (
 )
 
 const (
-	ReadUnitPrice  = 0.06
+	ReadUnitPrice  = 6 * Cent
 	FreeReadsDaily = 50000
 )
 
-type DailyReadCalculator struct{}
+type DailyReadCalculator struct {
+	// Unit Price.
+	// Price per Unit, zero bills nothing.
+	Price Money
 
-func (dw *DailyReadCalculator) Calculate(_ context.Context, count *big.Int) (*big.Float, error) {
-	free := big.NewInt(FreeReadsDaily)
-	b := count.Sub(count, free)
-	billable := new(big.Float).SetInt(b)
+	// NoFreeTier bills every operation, for databases without the free
+	// quota.
+	NoFreeTier bool
+}
 
-	unit := new(big.Float).SetInt64(Unit)
-	unitPrice := big.NewFloat(ReadUnitPrice)
+// Exact returns the daily cost of count reads in micro-units, before
+// rounding.
+func (dw *DailyReadCalculator) Exact(_ context.Context, count *big.Int) (*big.Rat, error) {
+	billable := new(big.Int).Set(count)
+	if !dw.NoFreeTier {
+		billable.Sub(billable, big.NewInt(FreeReadsDaily))
+	}
 
-	q := billable.Quo(billable, unit)
+	return Amount(billable, dw.Price, Unit), nil
+}
 
-	daily := q.Mul(q, unitPrice)
+// Calculate returns the daily cost of count reads rounded to the billing
+// unit.
+func (dw *DailyReadCalculator) Calculate(ctx context.Context, count *big.Int) (Money, error) {
+	daily, err := dw.Exact(ctx, count)
+	if err != nil {
+		return 0, err
+	}
 
-	return daily, nil
+	return Bill(daily), nil
 }
 
 type MonthlyReadCalculator struct {
 	D *DailyReadCalculator
 }
 
-func (mw *MonthlyReadCalculator) Calculate(ctx context.Context, count *big.Int) (*big.Float, error) {
-	daily, err := mw.D.Calculate(ctx, count)
+func (mw *MonthlyReadCalculator) Calculate(ctx context.Context, count *big.Int) (Money, error) {
+	daily, err := mw.D.Exact(ctx, count)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	days := new(big.Float).SetInt64(int64(MonthNumOfDays))
-	monthly := daily.Mul(daily, days)
+	// The month is one invoice line, rounded once.
+	monthly := daily.Mul(daily, big.NewRat(MonthNumOfDays, 1))
 
-	return monthly, nil
+	return Bill(monthly), nil
 }
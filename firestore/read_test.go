@@ -9,17 +9,15 @@ import (
 )
 
 func Test_DailyReadCalculator_Calculate(t *testing.T) {
-	calc := &firestore.DailyReadCalculator{}
-	want := 0.21
+	calc := &firestore.DailyReadCalculator{Price: firestore.ReadUnitPrice}
+	want := 21 * firestore.Cent
 
 	dailyReads := big.NewInt(400000)
-	res, err := calc.Calculate(context.Background(), dailyReads)
+	got, err := calc.Calculate(context.Background(), dailyReads)
 	if err != nil {
 		t.Fatalf("unable to calculate daily reads: %v", err)
 	}
 
-	got, _ := res.Float64()
-
 	if want != got {
 		t.Errorf("want Calculate() result to be %v, got %v", want, got)
 	}
@@ -27,18 +25,31 @@ func Test_DailyReadCalculator_Calculate(t *testing.T) {
 
 func Test_MonthlyReadCalculator_Calculate(t *testing.T) {
 	calc := &firestore.MonthlyReadCalculator{
-		D: &firestore.DailyReadCalculator{},
+		D: &firestore.DailyReadCalculator{Price: firestore.ReadUnitPrice},
 	}
 	// 0.21 * 30
-	want := 6.3
+	want := 21 * firestore.Cent * 30
 
 	dailyReads := big.NewInt(400000)
-	res, err := calc.Calculate(context.Background(), dailyReads)
+	got, err := calc.Calculate(context.Background(), dailyReads)
 	if err != nil {
 		t.Fatalf("unable to calculate daily reads: %v", err)
 	}
 
-	got, _ := res.Float64()
+	if want != got {
+		t.Errorf("want Calculate() result to be %v, got %v", want, got)
+	}
+}
+
+func Test_DailyReadCalculator_Calculate_NoFreeTier(t *testing.T) {
+	calc := &firestore.DailyReadCalculator{Price: firestore.ReadUnitPrice, NoFreeTier: true}
+	want := 6 * firestore.Cent
+
+	dailyReads := big.NewInt(100000)
+	got, err := calc.Calculate(context.Background(), dailyReads)
+	if err != nil {
+		t.Fatalf("unable to calculate daily reads: %v", err)
+	}
 
 	if want != got {
 		t.Errorf("want Calculate() result to be %v, got %v", want, got)
@@ -0,0 +1,99 @@
+package firestore
+
+import (
+	"math/big"
+)
+
+// Subcollection is a collection of documents stored under every document of
+// its parent collection.
+type Subcollection struct {
+	// Name identifies the subcollection in reports.
+	Name string `json:"name"`
+
+	// FanOut is the average number of documents under every parent document.
+	FanOut float64 `json:"fan_out"`
+
+	// Subcollections are the collections under every document of the
+	// subcollection.
+	Subcollections []*Subcollection `json:"subcollections,omitempty"`
+}
+
+// RecursiveDelete is the deletion of documents with every document of their
+// subcollections, like recursiveDelete of the Admin SDKs. Every descendant
+// document is found by a query, billed as one read per document found or one
+// read when it finds none, and deleted with a billed delete.
+type RecursiveDelete struct {
+	// Subcollections are the collections under every deleted document.
+	Subcollections []*Subcollection `json:"subcollections"`
+}
+
+// Cascade returns the number of documents of every subcollection deleted with
+// count documents by name. Subcollections of the same name are added up.
+func (rd *RecursiveDelete) Cascade(count *big.Int) map[string]*big.Int {
+	cascade := map[string]*big.Int{}
+
+	var walk func(subs []*Subcollection, parents *big.Rat)
+	walk = func(subs []*Subcollection, parents *big.Rat) {
+		for _, s := range subs {
+			fanOut := new(big.Rat).SetFloat64(s.FanOut)
+			if fanOut == nil {
+				fanOut = new(big.Rat)
+			}
+			docs := fanOut.Mul(fanOut, parents)
+
+			n, ok := cascade[s.Name]
+			if !ok {
+				n = new(big.Int)
+				cascade[s.Name] = n
+			}
+			n.Add(n, round(docs.Num(), docs.Denom(), RoundHalfUp))
+
+			walk(s.Subcollections, docs)
+		}
+	}
+	walk(rd.Subcollections, new(big.Rat).SetInt(count))
+
+	return cascade
+}
+
+// Descendants returns the number of subcollection documents deleted with
+// count documents.
+func (rd *RecursiveDelete) Descendants(count *big.Int) *big.Int {
+	total := new(big.Int)
+	for _, n := range rd.Cascade(count) {
+		total.Add(total, n)
+	}
+
+	return total
+}
+
+// Deletes returns the number of billed deletes of deleting count documents
+// with their descendants.
+func (rd *RecursiveDelete) Deletes(count *big.Int) *big.Int {
+	return new(big.Int).Add(count, rd.Descendants(count))
+}
+
+// Reads returns the number of billed reads of the queries finding the
+// descendants of count documents: one read per descendant, and one read for
+// every document whose query finds none.
+//
+// With average fan-outs, a deleted document has descendants with the chance
+// of the sum of the fan-outs of its subcollections, capped at one.
+func (rd *RecursiveDelete) Reads(count *big.Int) *big.Int {
+	has := new(big.Rat)
+	for _, s := range rd.Subcollections {
+		if f := new(big.Rat).SetFloat64(s.FanOut); f != nil && f.Sign() > 0 {
+			has.Add(has, f)
+		}
+	}
+	if has.Cmp(big.NewRat(1, 1)) > 0 {
+		has.SetInt64(1)
+	}
+
+	empty := new(big.Rat).Sub(big.NewRat(1, 1), has)
+	empty.Mul(empty, new(big.Rat).SetInt(count))
+
+	reads := rd.Descendants(count)
+
+	return reads.Add(reads, round(empty.Num(), empty.Denom(), RoundHalfUp))
+}
@@ -0,0 +1,104 @@
+package firestore_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+func Test_RecursiveDelete(t *testing.T) {
+	profiles := &firestore.RecursiveDelete{
+		Subcollections: []*firestore.Subcollection{
+			{
+				Name:   "logs",
+				FanOut: 20,
+				Subcollections: []*firestore.Subcollection{
+					{Name: "entries", FanOut: 2.5},
+				},
+			},
+			{Name: "settings", FanOut: 0.5},
+		},
+	}
+
+	tt := []struct {
+		name    string
+		rd      *firestore.RecursiveDelete
+		count   int64
+		cascade map[string]int64
+		deletes int64
+		reads   int64
+	}{
+		{
+			"hierarchy",
+			profiles,
+			1000,
+			map[string]int64{"logs": 20000, "entries": 50000, "settings": 500},
+			71500,
+			70500,
+		},
+		{
+			// Every query is billed at least one read.
+			"no subcollections",
+			&firestore.RecursiveDelete{},
+			1000,
+			map[string]int64{},
+			1000,
+			1000,
+		},
+		{
+			// Half the profiles have settings, the other half are billed
+			// a read for a query that finds nothing.
+			"sparse",
+			&firestore.RecursiveDelete{
+				Subcollections: []*firestore.Subcollection{
+					{
+						Name:   "settings",
+						FanOut: 0.5,
+						Subcollections: []*firestore.Subcollection{
+							{Name: "history", FanOut: 10},
+						},
+					},
+				},
+			},
+			1000,
+			map[string]int64{"settings": 500, "history": 5000},
+			6500,
+			6000,
+		},
+		{
+			"none",
+			profiles,
+			0,
+			map[string]int64{"logs": 0, "entries": 0, "settings": 0},
+			0,
+			0,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			count := big.NewInt(tc.count)
+
+			cascade := tc.rd.Cascade(count)
+			if len(cascade) != len(tc.cascade) {
+				t.Errorf("want %d subcollections, got %v", len(tc.cascade), cascade)
+			}
+			for name, want := range tc.cascade {
+				if got := cascade[name]; got == nil || got.Int64() != want {
+					t.Errorf("want %v %s deletes, got %v", want, name, got)
+				}
+			}
+
+			if got := tc.rd.Deletes(count); got.Int64() != tc.deletes {
+				t.Errorf("want %v deletes, got %v", tc.deletes, got)
+			}
+
+			if got := tc.rd.Reads(count); got.Int64() != tc.reads {
+				t.Errorf("want %v reads, got %v", tc.reads, got)
+			}
+		})
+	}
+}
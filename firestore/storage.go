@@ -18,7 +18,7 @@ const (
 	OneGB = 1000000000
 
 	// PricePerGB is storage price per GB after free.
-	PricePerGB = 0.18
+	PricePerGB = 18 * Cent
 
 	// DocumentNamePadding is the default additional bytes for document name.
 	DocumentNamePadding = 16 // bytes
@@ -31,15 +31,22 @@ const (
 type DailyStorageCalculator struct {
 	// Document on disk.
 	Document *Document
+
+	// Size overrides the document size in bytes when set.
+	Size int64
 }
 
-func (ds *DailyStorageCalculator) Calculate(_ context.Context, count *big.Int) (*big.Float, error) {
+// Calculate returns the number of bytes stored daily.
+func (ds *DailyStorageCalculator) Calculate(_ context.Context, count *big.Int) (*big.Int, error) {
 	// Get document size in bytes.
-	size := big.NewInt(ds.Document.Size())
+	size := big.NewInt(ds.Size)
+	if ds.Size == 0 {
+		size.SetInt64(ds.Document.Size())
+	}
 
 	daily := size.Mul(size, count)
 
-	return new(big.Float).SetInt(daily), nil
+	return daily, nil
 }
 
 type MonthlyStorageCalculator struct {
@@ -47,24 +54,31 @@ type MonthlyStorageCalculator struct {
 
 	// Unit Price.
 	// Price per GB.
-	Price float64
+	Price Money
+
+	// NoFreeTier bills all the stored data, for databases without the free
+	// quota.
+	NoFreeTier bool
+
+	// Days is the number of days of stored documents of the month, defaults
+	// to MonthNumOfDays.
+	Days int64
 }
 
-func (ms *MonthlyStorageCalculator) Calculate(ctx context.Context, count *big.Int) (*big.Float, error) {
+func (ms *MonthlyStorageCalculator) Calculate(ctx context.Context, count *big.Int) (Money, error) {
 	daily, err := ms.D.Calculate(ctx, count)
 	if err != nil {
-		return new(big.Float), err
+		return 0, err
 	}
-	days := new(big.Float).SetInt64(MonthNumOfDays)
-
-	monthly := daily.Mul(daily, days)
+	monthly := daily.Mul(daily, big.NewInt(days(ms.Days)))
 
-	free := new(big.Float).SetInt64(MonthlyFreeStorage)
+	if !ms.NoFreeTier {
+		free := big.NewInt(MonthlyFreeStorage)
 
-	monthly = monthly.Sub(monthly, free)
-	monthly = monthly.Quo(monthly, new(big.Float).SetInt64(OneGB))
+		monthly = monthly.Sub(monthly, free)
+	}
 
-	cost := monthly.Mul(monthly, new(big.Float).SetFloat64(ms.Price))
+	cost := Charge(monthly, ms.Price, OneGB)
 
 	return cost, nil
 }
@@ -72,34 +86,28 @@ func (ms *MonthlyStorageCalculator) Calculate(ctx context.Context, count *big.In
 // Document defines the stored Firestore document.
 type Document struct {
 	// ID is the document id.
-	ID string
+	ID string `json:"id"`
 
 	// Collection is the collection name.
-	Collection string
+	Collection string `json:"collection"`
 
 	// Data contains the document fields and values.
-	Data map[string]interface{}
+	Data map[string]interface{} `json:"data"`
 
 	// SingleFieldIndexes represents a single-field indexes.
-	SingleFieldIndexes []map[string]interface{}
+	SingleFieldIndexes []map[string]interface{} `json:"single_field_indexes,omitempty"`
 
 	// CompositeIndexes represents composite indexes.
-	CompositeIndexes []map[string]interface{}
+	CompositeIndexes []map[string]interface{} `json:"composite_indexes,omitempty"`
 }
 
 // Calculate the document name total size size.
 func (d *Document) nameSize() int64 {
-	idSize := len(d.ID) + 1
-	cols := strings.Split(d.Collection, "/")
-
-	collSize := len(cols)
-	for _, c := range cols {
-		c := c
-		collSize += len(c)
+	size := idSize(d.ID) + DocumentNamePadding
+	for _, c := range strings.Split(d.Collection, "/") {
+		size += idSize(c)
 	}
 
-	size := int64(idSize + collSize + DocumentNamePadding)
-
 	return size
 }
 
@@ -109,10 +117,9 @@ func (d *Document) parentNameSize() (size int64) {
 
 	parent := cols[:len(cols)-1]
 
-	size += int64(len(parent)) + DocumentNamePadding
+	size += DocumentNamePadding
 	for _, c := range parent {
-		c := c
-		size += int64(len(c))
+		size += idSize(c)
 	}
 
 	if len(cols) < 3 {
@@ -186,18 +193,55 @@ func (d *Document) Size() (size int64) {
 	return size
 }
 
+// SizeBreakdown is the size of every part of a document in bytes.
+type SizeBreakdown struct {
+	Name               int64 `json:"name"`
+	Data               int64 `json:"data"`
+	SingleFieldIndexes int64 `json:"single_field_indexes"`
+	CompositeIndexes   int64 `json:"composite_indexes"`
+	Total              int64 `json:"total"`
+}
+
+// Breakdown calculate and return the size of every part of the document.
+func (d *Document) Breakdown() SizeBreakdown {
+	return SizeBreakdown{
+		Name:               d.nameSize(),
+		Data:               d.dataSize(),
+		SingleFieldIndexes: d.singleFieldIndexSize(),
+		CompositeIndexes:   d.compositeIndexSize(),
+		Total:              d.Size(),
+	}
+}
+
+// GeoPoint is a geographical point value.
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
 // Get value size.
-// TODO: Create unit tests.
 func getValueSize(val interface{}) int {
 	switch v := val.(type) {
+	case nil:
+		return 1
 	case string:
 		return len(v) + 1
+	case []byte:
+		return len(v)
 	case bool, byte:
 		return 1
-	case int, float64, time.Time:
+	case int, int64, float64, time.Time:
 		return 8
+	case GeoPoint:
+		return 16
 	case map[string]interface{}:
 		return int(getSize(v))
+	case []interface{}:
+		size := 0
+		for _, e := range v {
+			size += getValueSize(e)
+		}
+		return size
 	default:
 		return 0
 	}
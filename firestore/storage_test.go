@@ -55,9 +55,46 @@ func Test_MonthlyStorageCalculator_Calculate(t *testing.T) {
 		t.Fatalf("unable to calculate storage cost: %v", err)
 	}
 
-	fmt.Println("Storage Cost: $", cost)
+	// 34.9067... rounded to the cent.
+	want := 3491 * firestore.Cent
 
-	t.Error("TODO!")
+	if want != cost {
+		t.Errorf("want Calculate() result to be %v, got %v", want, cost)
+	}
+}
+
+func Test_MonthlyStorageCalculator_Calculate_NoFreeTier(t *testing.T) {
+	tt := []struct {
+		name       string
+		noFreeTier bool
+		want       firestore.Money
+	}{
+		// (30,000,000,000 - 1,073,741,824) / 1,000,000,000 * 0.18
+		{"free tier", false, 521 * firestore.Cent},
+		// 30GB * 0.18
+		{"no free tier", true, 540 * firestore.Cent},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			calc := &firestore.MonthlyStorageCalculator{
+				D:          &firestore.DailyStorageCalculator{Size: 1000},
+				Price:      firestore.PricePerGB,
+				NoFreeTier: tc.noFreeTier,
+			}
+
+			got, err := calc.Calculate(context.Background(), big.NewInt(1000000))
+			if err != nil {
+				t.Fatalf("unable to calculate storage cost: %v", err)
+			}
+
+			if tc.want != got {
+				t.Errorf("want Calculate() result to be %v, got %v", tc.want, got)
+			}
+		})
+	}
 }
 
 func Test_Document_Size(t *testing.T) {
@@ -195,6 +232,31 @@ func TestGetValueSize(t *testing.T) {
 			// 12 - field2 & hello
 			32 + 2 + 7 + 12,
 		},
+		{
+			"null",
+			nil,
+			1,
+		},
+		{
+			"int64",
+			int64(1),
+			8,
+		},
+		{
+			"bytes",
+			[]byte("abc"),
+			3,
+		},
+		{
+			"geopoint",
+			firestore.GeoPoint{Latitude: 14.5, Longitude: 121},
+			16,
+		},
+		{
+			"array",
+			[]interface{}{"apple", int64(1), true},
+			6 + 8 + 1,
+		},
 	}
 
 	for _, tc := range tt {
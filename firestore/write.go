@@ -7,39 +7,54 @@ import (
 
 const (
 	Unit            = 100000
-	WriteUnitPrice  = 0.18
+	WriteUnitPrice  = 18 * Cent
 	FreeWritesDaily = 20000
 )
 
-type DailyWriteCalculator struct{}
+type DailyWriteCalculator struct {
+	// Unit Price.
+	// Price per Unit, zero bills nothing.
+	Price Money
 
-func (dw *DailyWriteCalculator) Calculate(_ context.Context, count *big.Int) (*big.Float, error) {
-	free := big.NewInt(FreeWritesDaily)
-	b := count.Sub(count, free)
-	billable := new(big.Float).SetInt(b)
+	// NoFreeTier bills every operation, for databases without the free
+	// quota.
+	NoFreeTier bool
+}
 
-	unit := new(big.Float).SetInt64(Unit)
-	unitPrice := big.NewFloat(WriteUnitPrice)
+// Exact returns the daily cost of count writes in micro-units, before
+// rounding.
+func (dw *DailyWriteCalculator) Exact(_ context.Context, count *big.Int) (*big.Rat, error) {
+	billable := new(big.Int).Set(count)
+	if !dw.NoFreeTier {
+		billable.Sub(billable, big.NewInt(FreeWritesDaily))
+	}
 
-	q := billable.Quo(billable, unit)
+	return Amount(billable, dw.Price, Unit), nil
+}
 
-	daily := q.Mul(q, unitPrice)
+// Calculate returns the daily cost of count writes rounded to the billing
+// unit.
+func (dw *DailyWriteCalculator) Calculate(ctx context.Context, count *big.Int) (Money, error) {
+	daily, err := dw.Exact(ctx, count)
+	if err != nil {
+		return 0, err
+	}
 
-	return daily, nil
+	return Bill(daily), nil
 }
 
 type MonthlyWriteCalculator struct {
 	D *DailyWriteCalculator
 }
 
-func (mw *MonthlyWriteCalculator) Calculate(ctx context.Context, count *big.Int) (*big.Float, error) {
-	daily, err := mw.D.Calculate(ctx, count)
+func (mw *MonthlyWriteCalculator) Calculate(ctx context.Context, count *big.Int) (Money, error) {
+	daily, err := mw.D.Exact(ctx, count)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	days := new(big.Float).SetInt64(int64(MonthNumOfDays))
-	monthly := daily.Mul(daily, days)
+	// The month is one invoice line, rounded once.
+	monthly := daily.Mul(daily, big.NewRat(MonthNumOfDays, 1))
 
-	return monthly, nil
+	return Bill(monthly), nil
 }
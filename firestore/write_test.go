@@ -9,17 +9,15 @@ import (
 )
 
 func Test_DailyWriteCalculator_Calculate(t *testing.T) {
-	calc := &firestore.DailyWriteCalculator{}
-	want := 0.14
+	calc := &firestore.DailyWriteCalculator{Price: firestore.WriteUnitPrice}
+	want := 14 * firestore.Cent
 
 	dailyWrites := big.NewInt(100000)
-	res, err := calc.Calculate(context.Background(), dailyWrites)
+	got, err := calc.Calculate(context.Background(), dailyWrites)
 	if err != nil {
 		t.Fatalf("unable to calculate daily writes: %v", err)
 	}
 
-	got, _ := res.Float64()
-
 	if want != got {
 		t.Errorf("want Calculate() result to be %v, got %v", want, got)
 	}
@@ -27,18 +25,31 @@ func Test_DailyWriteCalculator_Calculate(t *testing.T) {
 
 func Test_MonthlyWriteCalculator_Calculate(t *testing.T) {
 	calc := &firestore.MonthlyWriteCalculator{
-		D: &firestore.DailyWriteCalculator{},
+		D: &firestore.DailyWriteCalculator{Price: firestore.WriteUnitPrice},
 	}
-	// 0.14 * 30
-	want := 4.20
+	// 0.144 * 30 = 4.32, rounded once for the month instead of every day.
+	want := 432 * firestore.Cent
 
 	dailyWrites := big.NewInt(100000)
-	res, err := calc.Calculate(context.Background(), dailyWrites)
+	got, err := calc.Calculate(context.Background(), dailyWrites)
 	if err != nil {
 		t.Fatalf("unable to calculate daily writes: %v", err)
 	}
 
-	got, _ := res.Float64()
+	if want != got {
+		t.Errorf("want Calculate() result to be %v, got %v", want, got)
+	}
+}
+
+func Test_DailyWriteCalculator_Calculate_NoFreeTier(t *testing.T) {
+	calc := &firestore.DailyWriteCalculator{Price: firestore.WriteUnitPrice, NoFreeTier: true}
+	want := 18 * firestore.Cent
+
+	dailyWrites := big.NewInt(100000)
+	got, err := calc.Calculate(context.Background(), dailyWrites)
+	if err != nil {
+		t.Fatalf("unable to calculate daily writes: %v", err)
+	}
 
 	if want != got {
 		t.Errorf("want Calculate() result to be %v, got %v", want, got)
@@ -5,4 +5,5 @@ go 1.13
 require (
 	github.com/google/uuid v1.6.0
 	github.com/spf13/cobra v1.8.1
+	github.com/spf13/pflag v1.0.5
 )
@@ -3,6 +3,31 @@ package main
 import "github.com/royge/gostcalc/cmd"
 
 func main() {
-	cmd.Register(cmd.RegisterFirestore)
+	cmd.Register(
+		cmd.RegisterConfig,
+		cmd.RegisterCurrency,
+		cmd.RegisterFirestore,
+		cmd.RegisterBackup,
+		cmd.RegisterExports,
+		cmd.RegisterCompare,
+		cmd.RegisterSensitivity,
+		cmd.RegisterMonteCarlo,
+		cmd.RegisterSolve,
+		cmd.RegisterAdvise,
+		cmd.RegisterServe,
+		cmd.RegisterUI,
+		cmd.RegisterWizard,
+		cmd.RegisterExport,
+		cmd.RegisterSample,
+		cmd.RegisterActual,
+		cmd.RegisterReconcile,
+		cmd.RegisterCheck,
+		cmd.RegisterNetCost,
+		cmd.RegisterCalendar,
+		cmd.RegisterModel,
+		cmd.RegisterProject,
+		cmd.RegisterIDs,
+		cmd.RegisterRecursiveDelete,
+	)
 	cmd.Execute()
 }
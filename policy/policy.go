@@ -0,0 +1,180 @@
+// Package policy checks estimates against cost limits.
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+)
+
+// Policy is the cost limits of an estimate. Limits that are not set are not
+// checked.
+type Policy struct {
+	// MaxTotal is the highest total monthly cost.
+	MaxTotal *firestore.Money `json:"max_total,omitempty"`
+
+	// MaxCategory is the highest monthly cost of each category.
+	MaxCategory map[scenario.Category]firestore.Money `json:"max_category,omitempty"`
+
+	// MaxPerUser is the highest total monthly cost per active user.
+	MaxPerUser *firestore.Money `json:"max_per_user,omitempty"`
+
+	// MaxIncreasePercent is the highest increase of the total over the
+	// baseline estimate, in percent.
+	MaxIncreasePercent *float64 `json:"max_increase_percent,omitempty"`
+
+	// Baseline is the scenario file of the baseline estimate, relative to
+	// the policy file.
+	Baseline string `json:"baseline,omitempty"`
+}
+
+// Read decodes and validates a JSON policy. Category names are case
+// insensitive.
+func Read(r io.Reader) (*Policy, error) {
+	d := json.NewDecoder(r)
+	d.DisallowUnknownFields()
+
+	p := &Policy{}
+	if err := d.Decode(p); err != nil {
+		return nil, fmt.Errorf("unable to decode policy: %w", err)
+	}
+
+	caps := map[scenario.Category]firestore.Money{}
+	for name, limit := range p.MaxCategory {
+		c, ok := scenario.ParseCategory(string(name))
+		if !ok {
+			return nil, fmt.Errorf("invalid policy: unknown category %q", name)
+		}
+		caps[c] = limit
+	}
+	if len(caps) > 0 {
+		p.MaxCategory = caps
+	}
+
+	if p.MaxIncreasePercent != nil && *p.MaxIncreasePercent < 0 {
+		return nil, fmt.Errorf("invalid policy: max_increase_percent %v is negative", *p.MaxIncreasePercent)
+	}
+
+	return p, nil
+}
+
+// Load reads a JSON policy file. A relative baseline path is resolved against
+// the directory of the file.
+func Load(path string) (*Policy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open policy: %w", err)
+	}
+	defer f.Close()
+
+	p, err := Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if p.Baseline != "" && !filepath.IsAbs(p.Baseline) {
+		p.Baseline = filepath.Join(filepath.Dir(path), p.Baseline)
+	}
+
+	return p, nil
+}
+
+// Result is the outcome of a rule.
+type Result struct {
+	Rule   string
+	Limit  string
+	Actual string
+	Pass   bool
+}
+
+// Check evaluates every limit of p against the estimate e of s. The increase
+// limit fails when baseline is nil, there is nothing to compare against.
+func (p *Policy) Check(s *scenario.Scenario, e, baseline scenario.Estimate) []Result {
+	var results []Result
+
+	if p.MaxTotal != nil {
+		results = append(results, Result{
+			Rule:   "Total",
+			Limit:  p.MaxTotal.String(),
+			Actual: e.Total().String(),
+			Pass:   e.Total() <= *p.MaxTotal,
+		})
+	}
+
+	for _, c := range scenario.Categories {
+		limit, ok := p.MaxCategory[c]
+		if !ok {
+			continue
+		}
+
+		results = append(results, Result{
+			Rule:   string(c),
+			Limit:  limit.String(),
+			Actual: e[c].String(),
+			Pass:   e[c] <= limit,
+		})
+	}
+
+	if p.MaxPerUser != nil {
+		r := Result{Rule: "Per user", Limit: p.MaxPerUser.String(), Actual: "n/a", Pass: true}
+
+		if s.Population > 0 {
+			perUser := new(big.Rat).SetFrac64(int64(e.Total()), s.Population*int64(firestore.Dollar))
+			r.Actual = firestore.RatMoney(perUser).String()
+
+			// Compare exactly, the per user cost can be below a micro-unit.
+			limit := new(big.Int).Mul(big.NewInt(int64(*p.MaxPerUser)), big.NewInt(s.Population))
+			r.Pass = big.NewInt(int64(e.Total())).Cmp(limit) <= 0
+		}
+
+		results = append(results, r)
+	}
+
+	if p.MaxIncreasePercent != nil {
+		r := Result{
+			Rule:  "Increase",
+			Limit: fmt.Sprintf("%+.1f%%", *p.MaxIncreasePercent),
+			Pass:  true,
+		}
+
+		var base, total firestore.Money
+		if baseline != nil {
+			base, total = baseline.Total(), e.Total()
+		}
+
+		switch {
+		case baseline == nil:
+			r.Actual, r.Pass = "no baseline", false
+		case base == 0 && total == 0:
+			r.Actual = "+0.0%"
+		case base == 0:
+			r.Actual, r.Pass = "n/a", false
+		default:
+			increase := new(big.Rat).SetFrac64(int64(total-base)*100, int64(base))
+			f, _ := increase.Float64()
+			r.Actual = fmt.Sprintf("%+.1f%%", f)
+			r.Pass = increase.Cmp(new(big.Rat).SetFloat64(*p.MaxIncreasePercent)) <= 0
+		}
+
+		results = append(results, r)
+	}
+
+	return results
+}
+
+// Passed reports whether every result passed.
+func Passed(results []Result) bool {
+	for _, r := range results {
+		if !r.Pass {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,124 @@
+package policy_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/policy"
+	"github.com/royge/gostcalc/scenario"
+)
+
+func TestCheck(t *testing.T) {
+	s := scenario.Default()
+	s.Population = 1000
+
+	e := scenario.Estimate{
+		scenario.CategoryWrite:   60 * firestore.Dollar,
+		scenario.CategoryRead:    30 * firestore.Dollar,
+		scenario.CategoryStorage: 10 * firestore.Dollar,
+	}
+	baseline := scenario.Estimate{scenario.CategoryWrite: 90 * firestore.Dollar}
+
+	tt := []struct {
+		name   string
+		policy string
+		want   []policy.Result
+	}{
+		{
+			"total",
+			`{"max_total": "100.00"}`,
+			[]policy.Result{{Rule: "Total", Limit: "100.00", Actual: "100.00", Pass: true}},
+		},
+		{
+			"categories",
+			`{"max_category": {"write": 50, "Storage": "10"}}`,
+			[]policy.Result{
+				{Rule: "Write", Limit: "50.00", Actual: "60.00", Pass: false},
+				{Rule: "Storage", Limit: "10.00", Actual: "10.00", Pass: true},
+			},
+		},
+		{
+			"per user",
+			`{"max_per_user": "0.0999"}`,
+			[]policy.Result{{Rule: "Per user", Limit: "0.0999", Actual: "0.10", Pass: false}},
+		},
+		{
+			"increase",
+			`{"max_increase_percent": 10}`,
+			[]policy.Result{{Rule: "Increase", Limit: "+10.0%", Actual: "+11.1%", Pass: false}},
+		},
+		{
+			"increase at limit",
+			`{"max_increase_percent": 11.2}`,
+			[]policy.Result{{Rule: "Increase", Limit: "+11.2%", Actual: "+11.1%", Pass: true}},
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := policy.Read(strings.NewReader(tc.policy))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := p.Check(s, e, baseline)
+			if len(got) != len(tc.want) {
+				t.Fatalf("want %v, got %v", tc.want, got)
+			}
+
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("want %+v, got %+v", tc.want[i], got[i])
+				}
+			}
+
+			pass := true
+			for _, r := range tc.want {
+				pass = pass && r.Pass
+			}
+			if policy.Passed(got) != pass {
+				t.Errorf("want Passed() %v", pass)
+			}
+		})
+	}
+}
+
+func TestCheckWithoutBaseline(t *testing.T) {
+	p, err := policy.Read(strings.NewReader(`{"max_increase_percent": 10}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := p.Check(scenario.Default(), scenario.Estimate{}, nil)
+	if len(got) != 1 || got[0].Pass || got[0].Actual != "no baseline" {
+		t.Errorf("want a failed increase rule, got %v", got)
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	for _, input := range []string{
+		`{"max_category": {"egress": 1}}`,
+		`{"max_total": "lots"}`,
+		`{"max_increase_percent": -1}`,
+		`{"max_totl": 1}`,
+	} {
+		if _, err := policy.Read(strings.NewReader(input)); err == nil {
+			t.Errorf("%s: want error, got nil", input)
+		}
+	}
+}
+
+func TestLoadBaseline(t *testing.T) {
+	p, err := policy.Load(filepath.Join("testdata", "policy.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := filepath.Join("testdata", "base.json"); p.Baseline != want {
+		t.Errorf("want baseline %v, got %v", want, p.Baseline)
+	}
+}
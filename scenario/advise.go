@@ -0,0 +1,382 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+const (
+	// LongFieldName is the length above which field names are worth
+	// shortening.
+	LongFieldName = 10
+
+	// SuggestedTTLDays is the TTL suggested for log collections.
+	SuggestedTTLDays = 7
+)
+
+// Kinds of suggestions.
+const (
+	SuggestShortenField  = "shorten-field"
+	SuggestExemptIndex   = "exempt-index"
+	SuggestDropIndex     = "drop-index"
+	SuggestAddTTL        = "add-ttl"
+	SuggestMergeDocument = "merge-documents"
+)
+
+// logCollections are collection name parts that hold logs.
+var logCollections = []string{"log", "event", "audit", "history", "activit"}
+
+// Suggestion is a change of a scenario that saves money.
+type Suggestion struct {
+	// Kind of suggestion.
+	Kind string
+
+	// Description of the change.
+	Description string
+
+	// Savings is the decrease of the total monthly cost.
+	Savings firestore.Money
+
+	// Scenario is the changed scenario.
+	Scenario *Scenario
+}
+
+// Advise returns the suggestions that lower the monthly cost of s, ranked by
+// savings.
+func Advise(ctx context.Context, p firestore.PriceList, s *Scenario) ([]Suggestion, error) {
+	e, err := s.Estimate(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []Suggestion
+	candidates = append(candidates, shortenFields(s)...)
+	candidates = append(candidates, exemptIndexes(s)...)
+	candidates = append(candidates, dropIndexes(s)...)
+	candidates = append(candidates, addTTL(s)...)
+	candidates = append(candidates, mergeDocuments(s)...)
+
+	var suggestions []Suggestion
+	for _, c := range candidates {
+		ce, err := c.Scenario.Estimate(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+
+		c.Savings = e.Total() - ce.Total()
+		if c.Savings > 0 {
+			suggestions = append(suggestions, c)
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Savings > suggestions[j].Savings
+	})
+
+	return suggestions, nil
+}
+
+// Suggest short names for fields with long names.
+func shortenFields(s *Scenario) []Suggestion {
+	used := map[string]bool{}
+	for k := range s.Document.Data {
+		used[k] = true
+	}
+
+	var suggestions []Suggestion
+	for _, k := range sortedKeys(s.Document.Data) {
+		if len(k) <= LongFieldName {
+			continue
+		}
+
+		short := abbreviate(k, used)
+		used[short] = true
+
+		c := *s
+		c.Document = cloneDocument(s.Document)
+		rename(c.Document.Data, k, short)
+		for _, idx := range c.Document.SingleFieldIndexes {
+			rename(idx, k, short)
+		}
+		for _, idx := range c.Document.CompositeIndexes {
+			rename(idx, k, short)
+		}
+
+		suggestions = append(suggestions, Suggestion{
+			Kind:        SuggestShortenField,
+			Description: fmt.Sprintf("Rename field %q to %q", k, short),
+			Scenario:    &c,
+		})
+	}
+
+	return suggestions
+}
+
+// Suggest exempting single-field indexes no query uses.
+func exemptIndexes(s *Scenario) []Suggestion {
+	queried := map[string]bool{}
+	for _, f := range s.QueriedFields {
+		queried[f] = true
+	}
+	if len(s.QueriedFields) == 0 {
+		for _, idx := range s.Document.CompositeIndexes {
+			for f := range idx {
+				queried[f] = true
+			}
+		}
+	}
+
+	var suggestions []Suggestion
+	for i, idx := range s.Document.SingleFieldIndexes {
+		fields := sortedKeys(idx)
+
+		used := false
+		for _, f := range fields {
+			used = used || queried[f]
+		}
+		if used {
+			continue
+		}
+
+		c := *s
+		c.Document = cloneDocument(s.Document)
+		c.Document.SingleFieldIndexes = remove(c.Document.SingleFieldIndexes, i)
+
+		suggestions = append(suggestions, Suggestion{
+			Kind: SuggestExemptIndex,
+			Description: fmt.Sprintf(
+				"Exempt unqueried field %s from single-field indexing",
+				strings.Join(fields, ", "),
+			),
+			Scenario: &c,
+		})
+	}
+
+	return suggestions
+}
+
+// Suggest dropping composite indexes whose fields another index covers.
+func dropIndexes(s *Scenario) []Suggestion {
+	var suggestions []Suggestion
+
+	indexes := s.Document.CompositeIndexes
+	for i, idx := range indexes {
+		for j, other := range indexes {
+			if i == j || !covers(other, idx) {
+				continue
+			}
+
+			// Keep the first of identical indexes.
+			if len(idx) == len(other) && j > i {
+				continue
+			}
+
+			c := *s
+			c.Document = cloneDocument(s.Document)
+			c.Document.CompositeIndexes = remove(c.Document.CompositeIndexes, i)
+
+			suggestions = append(suggestions, Suggestion{
+				Kind: SuggestDropIndex,
+				Description: fmt.Sprintf(
+					"Drop composite index (%s) covered by (%s)",
+					strings.Join(sortedKeys(idx), ", "),
+					strings.Join(sortedKeys(other), ", "),
+				),
+				Scenario: &c,
+			})
+
+			break
+		}
+	}
+
+	return suggestions
+}
+
+// Suggest a TTL policy for log collections.
+func addTTL(s *Scenario) []Suggestion {
+	if s.TTLDays > 0 && s.TTLDays <= SuggestedTTLDays {
+		return nil
+	}
+
+	parts := strings.Split(s.Document.Collection, "/")
+	name := strings.ToLower(parts[len(parts)-1])
+
+	for _, l := range logCollections {
+		if !strings.Contains(name, l) {
+			continue
+		}
+
+		c := *s
+		c.TTLDays = SuggestedTTLDays
+
+		return []Suggestion{{
+			Kind: SuggestAddTTL,
+			Description: fmt.Sprintf(
+				"Add a %d-day TTL policy to log collection %q",
+				SuggestedTTLDays,
+				parts[len(parts)-1],
+			),
+			Scenario: &c,
+		}}
+	}
+
+	return nil
+}
+
+// Suggest merging the documents read together by a transaction. The merged
+// document holds the fields and index entries of all of them. Fractional
+// reads are averages of transactions reading different numbers of documents,
+// which do not merge into one.
+func mergeDocuments(s *Scenario) []Suggestion {
+	if s.Reads <= 1 || s.Reads != math.Trunc(s.Reads) {
+		return nil
+	}
+
+	n := int(s.Reads)
+
+	c := *s
+	c.Reads = 1
+	c.Document = merge(s.Document, n)
+	c.DocumentSize = s.DocumentSize * int64(n)
+
+	return []Suggestion{{
+		Kind: SuggestMergeDocument,
+		Description: fmt.Sprintf(
+			"Merge the %v documents read per transaction into one",
+			s.Reads,
+		),
+		Scenario: &c,
+	}}
+}
+
+// Return a copy of d holding the fields of n documents like it, the ones of
+// every copy after the first suffixed with its number.
+func merge(d *firestore.Document, n int) *firestore.Document {
+	c := cloneDocument(d)
+
+	for i := 2; i <= n; i++ {
+		suffix := fmt.Sprintf("_%d", i)
+
+		for k, v := range d.Data {
+			c.Data[k+suffix] = v
+		}
+		for _, idx := range d.SingleFieldIndexes {
+			c.SingleFieldIndexes = append(c.SingleFieldIndexes, suffixed(idx, suffix))
+		}
+		for _, idx := range d.CompositeIndexes {
+			c.CompositeIndexes = append(c.CompositeIndexes, suffixed(idx, suffix))
+		}
+	}
+
+	return c
+}
+
+// Return a copy of m with every key suffixed.
+func suffixed(m map[string]interface{}, suffix string) map[string]interface{} {
+	c := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		c[k+suffix] = v
+	}
+
+	return c
+}
+
+// Tell whether every field of idx is in other.
+func covers(other, idx map[string]interface{}) bool {
+	if len(idx) > len(other) {
+		return false
+	}
+
+	for f := range idx {
+		if _, ok := other[f]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Abbreviate a snake_case or camelCase name to its initials, keeping it
+// unique among used names.
+func abbreviate(name string, used map[string]bool) string {
+	var b strings.Builder
+	start := true
+	for _, r := range name {
+		switch {
+		case r == '_' || r == '-' || r == '.':
+			start = true
+		case start || (r >= 'A' && r <= 'Z'):
+			b.WriteRune(r)
+			start = false
+		}
+	}
+
+	short := strings.ToLower(b.String())
+	if len(short) < 2 {
+		short = name[:2]
+	}
+
+	candidate := short
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", short, i)
+	}
+
+	return candidate
+}
+
+// Rename a key of m if present.
+func rename(m map[string]interface{}, from, to string) {
+	if v, ok := m[from]; ok {
+		delete(m, from)
+		m[to] = v
+	}
+}
+
+// Return a copy of s without item i.
+func remove(s []map[string]interface{}, i int) []map[string]interface{} {
+	c := append([]map[string]interface{}{}, s[:i]...)
+
+	return append(c, s[i+1:]...)
+}
+
+// Copy the document and its top-level maps so they can be changed.
+func cloneDocument(d *firestore.Document) *firestore.Document {
+	c := *d
+	c.Data = cloneMap(d.Data)
+
+	c.SingleFieldIndexes = nil
+	for _, idx := range d.SingleFieldIndexes {
+		c.SingleFieldIndexes = append(c.SingleFieldIndexes, cloneMap(idx))
+	}
+
+	c.CompositeIndexes = nil
+	for _, idx := range d.CompositeIndexes {
+		c.CompositeIndexes = append(c.CompositeIndexes, cloneMap(idx))
+	}
+
+	return &c
+}
+
+func cloneMap(m map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+
+	return c
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,155 @@
+package scenario_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+)
+
+func TestAdvise(t *testing.T) {
+	s, err := scenario.Read(strings.NewReader(`{
+		"reads": 3,
+		"document": {
+			"id": "AbCdEfGhIjKlMnOpQrSt",
+			"collection": "profiles/AbCdEfGhIjKlMnOpQrSt/logs",
+			"data": {
+				"merchant_identifier": "AbCdEfGhIjKlMnOpQrSt",
+				"created": "2026-10-01T00:00:00Z",
+				"note": "Scanned QR"
+			},
+			"single_field_indexes": [{"note": ""}],
+			"composite_indexes": [
+				{"merchant_identifier": "", "created": "2026-10-01T00:00:00Z"},
+				{"created": "2026-10-01T00:00:00Z"}
+			]
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("unable to read scenario: %v", err)
+	}
+
+	suggestions, err := scenario.Advise(context.Background(), firestore.DefaultPrices, s)
+	if err != nil {
+		t.Fatalf("unable to advise: %v", err)
+	}
+
+	kinds := map[string]bool{}
+	for i, sg := range suggestions {
+		kinds[sg.Kind] = true
+
+		if sg.Savings <= 0 {
+			t.Errorf("want positive savings, got %v for %v", sg.Savings, sg.Description)
+		}
+
+		if i > 0 && suggestions[i-1].Savings < sg.Savings {
+			t.Errorf("want suggestions ranked by savings, got %v before %v", suggestions[i-1].Savings, sg.Savings)
+		}
+	}
+
+	for _, kind := range []string{
+		scenario.SuggestShortenField,
+		scenario.SuggestExemptIndex,
+		scenario.SuggestDropIndex,
+		scenario.SuggestMergeDocument,
+	} {
+		if !kinds[kind] {
+			t.Errorf("want a %v suggestion, got none", kind)
+		}
+	}
+
+	for _, sg := range suggestions {
+		if sg.Kind != scenario.SuggestMergeDocument {
+			continue
+		}
+
+		// The 3 documents read together become one holding their fields.
+		if want, got := 3*len(s.Document.Data), len(sg.Scenario.Document.Data); want != got {
+			t.Errorf("want %v fields in the merged document, got %v", want, got)
+		}
+
+		if got := sg.Scenario.Document.Size(); got <= 2*s.Document.Size() {
+			t.Errorf("want the merged document to grow, got %v bytes from %v", got, s.Document.Size())
+		}
+	}
+
+	if _, ok := s.Document.Data["merchant_identifier"]; !ok {
+		t.Error("want the advised scenario unchanged")
+	}
+}
+
+func TestAdvise_FractionalReads(t *testing.T) {
+	s := scenario.Default()
+	s.Reads = 2.5
+
+	suggestions, err := scenario.Advise(context.Background(), firestore.DefaultPrices, s)
+	if err != nil {
+		t.Fatalf("unable to advise: %v", err)
+	}
+
+	for _, sg := range suggestions {
+		if sg.Kind == scenario.SuggestMergeDocument {
+			t.Errorf("want no merge suggestion for fractional reads, got %v", sg.Description)
+		}
+	}
+}
+
+func TestAdvise_TTL(t *testing.T) {
+	read := func(size int64) *scenario.Scenario {
+		s := scenario.Default()
+		s.Document.Collection = "audit_logs"
+		s.DocumentSize = size
+
+		return s
+	}
+
+	ctx := context.Background()
+
+	// The TTL deletes cost more than the storage of small documents saves.
+	small, err := scenario.Advise(ctx, firestore.DefaultPrices, read(500))
+	if err != nil {
+		t.Fatalf("unable to advise: %v", err)
+	}
+
+	for _, sg := range small {
+		if sg.Kind == scenario.SuggestAddTTL {
+			t.Errorf("want no TTL suggestion for small documents, got %v", sg.Savings)
+		}
+	}
+
+	large := read(10000)
+	suggestions, err := scenario.Advise(ctx, firestore.DefaultPrices, large)
+	if err != nil {
+		t.Fatalf("unable to advise: %v", err)
+	}
+
+	var ttl *scenario.Suggestion
+	for i := range suggestions {
+		if suggestions[i].Kind == scenario.SuggestAddTTL {
+			ttl = &suggestions[i]
+		}
+	}
+	if ttl == nil {
+		t.Fatal("want a TTL suggestion for large documents, got none")
+	}
+
+	before, err := large.Estimate(ctx, firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate: %v", err)
+	}
+
+	after, err := ttl.Scenario.Estimate(ctx, firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate: %v", err)
+	}
+
+	if after[scenario.CategoryDelete] <= before[scenario.CategoryDelete] {
+		t.Errorf(
+			"want the TTL deletes billed, got delete cost %v before and %v after",
+			before[scenario.CategoryDelete],
+			after[scenario.CategoryDelete],
+		)
+	}
+}
@@ -0,0 +1,220 @@
+package scenario
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+// DateLayout is the layout of holiday dates.
+const DateLayout = "2006-01-02"
+
+// MonthLayout is the layout of calendar months, e.g. 2026-02.
+const MonthLayout = "2006-01"
+
+// Profile is the relative daily traffic of weekdays, weekend days and
+// holidays. The days of a month are scaled so the average day has the
+// workload of the scenario.
+type Profile struct {
+	// Weekday is the relative traffic of Monday to Friday.
+	Weekday float64 `json:"weekday"`
+
+	// Weekend is the relative traffic of Saturday and Sunday.
+	Weekend float64 `json:"weekend"`
+
+	// Holiday is the relative traffic of holidays.
+	Holiday float64 `json:"holiday"`
+
+	// Holidays are the dates of the holidays in DateLayout.
+	Holidays []string `json:"holidays,omitempty"`
+}
+
+// DefaultProfile returns a profile of the same traffic every day.
+func DefaultProfile() *Profile {
+	return &Profile{Weekday: 1, Weekend: 1, Holiday: 1}
+}
+
+// UnmarshalJSON decodes p with the relative traffic of DefaultProfile for
+// the day types missing from b.
+func (p *Profile) UnmarshalJSON(b []byte) error {
+	type profile Profile
+
+	v := profile(*DefaultProfile())
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*p = Profile(v)
+
+	return nil
+}
+
+// Report the first invalid input of p.
+func (p *Profile) validate() error {
+	if p.Weekday < 0 || p.Weekend < 0 || p.Holiday < 0 {
+		return fmt.Errorf("profile traffic must not be negative")
+	}
+
+	for _, d := range p.Holidays {
+		if _, err := time.Parse(DateLayout, d); err != nil {
+			return fmt.Errorf("profile holiday %q is not a date", d)
+		}
+	}
+
+	return nil
+}
+
+// Days returns the traffic of every day of the calendar month of t, scaled
+// so the average day is 1. Days are the calendar days of Pacific Time, when
+// the daily free quota resets.
+func (p *Profile) Days(t time.Time) ([]float64, error) {
+	holidays := map[string]bool{}
+	for _, d := range p.Holidays {
+		holidays[d] = true
+	}
+
+	days := make([]float64, firestore.DaysIn(t))
+	loc := firestore.QuotaLocation()
+
+	var total float64
+	for i := range days {
+		day := time.Date(t.Year(), t.Month(), i+1, 0, 0, 0, 0, loc)
+
+		switch {
+		case holidays[day.Format(DateLayout)]:
+			days[i] = p.Holiday
+		case day.Weekday() == time.Saturday || day.Weekday() == time.Sunday:
+			days[i] = p.Weekend
+		default:
+			days[i] = p.Weekday
+		}
+
+		total += days[i]
+	}
+
+	if total == 0 {
+		return nil, fmt.Errorf("profile has no traffic in %s", t.Format(MonthLayout))
+	}
+
+	for i := range days {
+		days[i] *= float64(len(days)) / total
+	}
+
+	return days, nil
+}
+
+// EstimateMonth calculates the cost of every category of s in the calendar
+// month of t using the unit prices in p. Every day of the month is priced on
+// its own with the traffic of the profile of s and its own free quota, and
+// storage and ingress are billed for the days of the month. The categories
+// of backups and exports are the ones of Estimate.
+func (s *Scenario) EstimateMonth(ctx context.Context, p firestore.PriceList, t time.Time) (Estimate, error) {
+	profile := s.Profile
+	if profile == nil {
+		profile = DefaultProfile()
+	}
+
+	days, err := profile.Days(t)
+	if err != nil {
+		return nil, err
+	}
+	n := int64(len(days))
+
+	e, err := s.Estimate(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the costs backups and exports add to the averaged estimate.
+	core := *s
+	core.Backup, core.Export = nil, nil
+
+	averaged, err := core.Estimate(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	for c, cost := range averaged {
+		e[c] -= cost
+	}
+
+	data, err := json.Marshal(s.Document.Data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal data: %w", err)
+	}
+
+	txn := big.NewInt(s.Population * s.Count)
+
+	items := []struct {
+		category Category
+		calc     firestore.DailyCalculator
+		count    *big.Int
+	}{
+		{
+			CategoryNetwork,
+			&firestore.MonthlyNetworkingCalculator{
+				D: &firestore.DailyNetworkingCalculator{
+					Document: data,
+					Size:     s.DocumentSize,
+				},
+				Price: p.Ingress,
+				Days:  n,
+			},
+			txn,
+		},
+		{
+			CategoryWrite,
+			&firestore.CalendarMonthCalculator{
+				D:    &firestore.DailyWriteCalculator{Price: p.Write},
+				Days: days,
+			},
+			scale(txn, s.Writes),
+		},
+		{
+			CategoryRead,
+			&firestore.CalendarMonthCalculator{
+				D:    &firestore.DailyReadCalculator{Price: p.Read},
+				Days: days,
+			},
+			scale(txn, s.Reads),
+		},
+		{
+			CategoryDelete,
+			&firestore.CalendarMonthCalculator{
+				D:    &firestore.DailyDeleteCalculator{Price: p.Delete},
+				Days: days,
+			},
+			s.deletes(txn, n),
+		},
+		{
+			CategoryStorage,
+			&firestore.MonthlyStorageCalculator{
+				D: &firestore.DailyStorageCalculator{
+					Document: s.document(),
+					Size:     s.DocumentSize,
+				},
+				Price: p.Storage,
+				Days:  n,
+			},
+			retainedIn(txn, s.TTLDays, n),
+		},
+	}
+
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		cost, err := item.calc.Calculate(ctx, new(big.Int).Set(item.count))
+		if err != nil {
+			return nil, fmt.Errorf("unable to calculate %s cost: %w", item.category, err)
+		}
+
+		e[item.category] += cost
+	}
+
+	return e, nil
+}
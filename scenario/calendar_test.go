@@ -0,0 +1,166 @@
+package scenario_test
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+)
+
+// Return the calendar month of s.
+func month(t *testing.T, s string) time.Time {
+	t.Helper()
+
+	m, err := time.Parse(scenario.MonthLayout, s)
+	if err != nil {
+		t.Fatalf("unable to parse month: %v", err)
+	}
+
+	return m
+}
+
+func TestProfile_Days(t *testing.T) {
+	p := &scenario.Profile{
+		Weekday:  1,
+		Weekend:  0.2,
+		Holiday:  0,
+		Holidays: []string{"2026-02-16"},
+	}
+
+	days, err := p.Days(month(t, "2026-02"))
+	if err != nil {
+		t.Fatalf("unable to get days: %v", err)
+	}
+
+	if len(days) != 28 {
+		t.Fatalf("want 28 days, got %d", len(days))
+	}
+
+	// 19 weekdays, 8 weekend days and a holiday on Monday the 16th.
+	weekday := 28 / (19 + 8*0.2)
+
+	tt := []struct {
+		day  int
+		want float64
+	}{
+		{1, weekday * 0.2},
+		{2, weekday},
+		{16, 0},
+		{28, weekday * 0.2},
+	}
+
+	for _, tc := range tt {
+		if got := days[tc.day-1]; math.Abs(tc.want-got) > 1e-9 {
+			t.Errorf("want day %d traffic %v, got %v", tc.day, tc.want, got)
+		}
+	}
+
+	var total float64
+	for _, d := range days {
+		total += d
+	}
+	if math.Abs(total-28) > 1e-9 {
+		t.Errorf("want the average day to be 1, got total %v", total)
+	}
+
+	if _, err := (&scenario.Profile{Weekday: 0}).Days(month(t, "2026-02")); err == nil {
+		t.Error("want error for a month without traffic, got nil")
+	}
+}
+
+func TestScenario_EstimateMonth(t *testing.T) {
+	s, err := scenario.Read(strings.NewReader(`{
+		"population": 30000,
+		"count": 1,
+		"writes": 1,
+		"reads": 2,
+		"deletes": 0,
+		"document_size": 1000,
+		"backup": {"retention_days": 7, "interval_days": 1}
+	}`))
+	if err != nil {
+		t.Fatalf("unable to read scenario: %v", err)
+	}
+
+	ctx := context.Background()
+
+	averaged, err := s.Estimate(ctx, firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate scenario: %v", err)
+	}
+
+	// A flat profile in a month of 30 days is the averaged estimate.
+	e, err := s.EstimateMonth(ctx, firestore.DefaultPrices, month(t, "2026-04"))
+	if err != nil {
+		t.Fatalf("unable to estimate month: %v", err)
+	}
+
+	for _, c := range scenario.Used(averaged, e) {
+		if averaged[c] != e[c] {
+			t.Errorf("want %v cost %v, got %v", c, averaged[c], e[c])
+		}
+	}
+
+	// Weekends of a fifth of the weekday traffic leave free reads unused.
+	s.Profile = &scenario.Profile{Weekday: 1, Weekend: 0.2}
+
+	e, err = s.EstimateMonth(ctx, firestore.DefaultPrices, month(t, "2026-02"))
+	if err != nil {
+		t.Fatalf("unable to estimate month: %v", err)
+	}
+
+	tt := []struct {
+		category scenario.Category
+		want     firestore.Money
+	}{
+		// 20 weekdays of 30,000 * 28 / 21.6 = 38,889 writes,
+		// (38,889 - 20,000) / 100,000 * 0.18 * 20 = 0.68.
+		{scenario.CategoryWrite, 68 * firestore.Cent},
+		// 20 weekdays of 77,778 reads,
+		// (77,778 - 50,000) / 100,000 * 0.06 * 20 = 0.33.
+		{scenario.CategoryRead, 33 * firestore.Cent},
+		{scenario.CategoryBackup, averaged[scenario.CategoryBackup]},
+	}
+
+	for _, tc := range tt {
+		if got := e[tc.category]; tc.want != got {
+			t.Errorf("want %v cost %v, got %v", tc.category, tc.want, got)
+		}
+	}
+}
+
+func TestRead_InvalidProfile(t *testing.T) {
+	tt := []struct {
+		name  string
+		input string
+	}{
+		{"negative traffic", `{"profile": {"weekend": -1}}`},
+		{"holiday", `{"profile": {"holidays": ["2026-02-30"]}}`},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := scenario.Read(strings.NewReader(tc.input)); err == nil {
+				t.Error("want error, got nil")
+			}
+		})
+	}
+}
+
+func TestRead_Profile(t *testing.T) {
+	s, err := scenario.Read(strings.NewReader(`{"profile": {"weekend": 0.2}}`))
+	if err != nil {
+		t.Fatalf("unable to read scenario: %v", err)
+	}
+
+	want := &scenario.Profile{Weekday: 1, Weekend: 0.2, Holiday: 1}
+	if p := s.Profile; p.Weekday != want.Weekday || p.Weekend != want.Weekend || p.Holiday != want.Holiday {
+		t.Errorf("want profile %+v, got %+v", want, p)
+	}
+}
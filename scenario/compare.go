@@ -0,0 +1,210 @@
+package scenario
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+// Names of the inputs a scenario can change.
+const (
+	InputPopulation = "population"
+	InputCount      = "count"
+	InputWrites     = "writes"
+	InputReads      = "reads"
+	InputDeletes    = "deletes"
+	InputDocument   = "document"
+	InputTTLDays    = "ttl_days"
+	InputIDStrategy = "id_strategy"
+	InputBackup     = "backup"
+	InputExport     = "export"
+
+	// Interaction is the part of a difference no single input explains.
+	Interaction = "interaction"
+)
+
+// Attribution is the part of a total cost difference caused by one input.
+type Attribution struct {
+	// Input is the name of the changed input.
+	Input string
+
+	// Delta is the change of total monthly cost.
+	Delta firestore.Money
+}
+
+// Comparison holds the estimates of several scenarios.
+type Comparison struct {
+	// Scenarios compared, the first one is the base.
+	Scenarios []*Scenario
+
+	// Estimates of each scenario.
+	Estimates []Estimate
+
+	// Attributions of each scenario against the base. The base has none.
+	Attributions [][]Attribution
+}
+
+// Compare estimates every scenario and attributes the differences of each
+// scenario from the first to the inputs that changed.
+func Compare(ctx context.Context, p firestore.PriceList, scenarios ...*Scenario) (*Comparison, error) {
+	if len(scenarios) < 2 {
+		return nil, errors.New("at least two scenarios are required")
+	}
+
+	c := &Comparison{
+		Scenarios:    scenarios,
+		Estimates:    make([]Estimate, len(scenarios)),
+		Attributions: make([][]Attribution, len(scenarios)),
+	}
+
+	for i, s := range scenarios {
+		e, err := s.Estimate(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+
+		c.Estimates[i] = e
+	}
+
+	base := scenarios[0]
+	for i, s := range scenarios[1:] {
+		attrs, err := attribute(ctx, p, base, c.Estimates[0], s, c.Estimates[i+1])
+		if err != nil {
+			return nil, err
+		}
+
+		c.Attributions[i+1] = attrs
+	}
+
+	return c, nil
+}
+
+// Change the inputs of base to the ones of other one at a time.
+func attribute(
+	ctx context.Context,
+	p firestore.PriceList,
+	base *Scenario,
+	baseEstimate Estimate,
+	other *Scenario,
+	otherEstimate Estimate,
+) ([]Attribution, error) {
+	changes := []struct {
+		input   string
+		changed bool
+		apply   func(s *Scenario)
+	}{
+		{
+			InputPopulation,
+			base.Population != other.Population,
+			func(s *Scenario) { s.Population = other.Population },
+		},
+		{
+			InputCount,
+			base.Count != other.Count,
+			func(s *Scenario) { s.Count = other.Count },
+		},
+		{
+			InputWrites,
+			base.Writes != other.Writes,
+			func(s *Scenario) { s.Writes = other.Writes },
+		},
+		{
+			InputReads,
+			base.Reads != other.Reads,
+			func(s *Scenario) { s.Reads = other.Reads },
+		},
+		{
+			InputDeletes,
+			base.Deletes != other.Deletes,
+			func(s *Scenario) { s.Deletes = other.Deletes },
+		},
+		{
+			InputDocument,
+			!sameDocument(base.Document, other.Document),
+			func(s *Scenario) { s.Document = other.Document },
+		},
+		{
+			InputDocumentSize,
+			base.DocumentSize != other.DocumentSize,
+			func(s *Scenario) { s.DocumentSize = other.DocumentSize },
+		},
+		{
+			InputTTLDays,
+			base.TTLDays != other.TTLDays,
+			func(s *Scenario) { s.TTLDays = other.TTLDays },
+		},
+		{
+			InputIDStrategy,
+			base.IDStrategy != other.IDStrategy,
+			func(s *Scenario) { s.IDStrategy = other.IDStrategy },
+		},
+		{
+			InputBackup,
+			!reflect.DeepEqual(base.Backup, other.Backup),
+			func(s *Scenario) { s.Backup = other.Backup },
+		},
+		{
+			InputExport,
+			!reflect.DeepEqual(base.Export, other.Export),
+			func(s *Scenario) { s.Export = other.Export },
+		},
+	}
+
+	var (
+		attrs     []Attribution
+		explained firestore.Money
+	)
+	for _, ch := range changes {
+		if !ch.changed {
+			continue
+		}
+
+		s := *base
+		ch.apply(&s)
+
+		e, err := s.Estimate(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+
+		delta := e.Total() - baseEstimate.Total()
+		explained += delta
+
+		attrs = append(attrs, Attribution{Input: ch.input, Delta: delta})
+	}
+
+	rest := otherEstimate.Total() - baseEstimate.Total() - explained
+	if rest != 0 {
+		attrs = append(attrs, Attribution{Input: Interaction, Delta: rest})
+	}
+
+	return attrs, nil
+}
+
+// sameDocument reports whether a and b are billed alike: the same stored size
+// and the same payload size in transit. Documents that differ only in sample
+// values are not a change.
+func sameDocument(a, b *firestore.Document) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	if a.Size() != b.Size() {
+		return false
+	}
+
+	ad, err := json.Marshal(a.Data)
+	if err != nil {
+		return false
+	}
+
+	bd, err := json.Marshal(b.Data)
+	if err != nil {
+		return false
+	}
+
+	return len(ad) == len(bd)
+}
@@ -0,0 +1,77 @@
+package scenario_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+)
+
+func TestCompare(t *testing.T) {
+	base := scenario.Default()
+
+	other := scenario.Default()
+	other.Document = base.Document
+	other.Population *= 2
+	other.Writes = 2
+
+	c, err := scenario.Compare(context.Background(), firestore.DefaultPrices, base, other)
+	if err != nil {
+		t.Fatalf("unable to compare scenarios: %v", err)
+	}
+
+	attrs := c.Attributions[1]
+
+	var inputs []string
+	var sum firestore.Money
+	for _, a := range attrs {
+		inputs = append(inputs, a.Input)
+		sum += a.Delta
+	}
+
+	if len(inputs) < 2 || inputs[0] != scenario.InputPopulation || inputs[1] != scenario.InputWrites {
+		t.Errorf("want population and writes attributions, got %v", inputs)
+	}
+
+	if want := c.Estimates[1].Total() - c.Estimates[0].Total(); want != sum {
+		t.Errorf("want attributions to sum to %v, got %v", want, sum)
+	}
+}
+
+func TestCompare_SameSizeDocument(t *testing.T) {
+	read := func(id string) *scenario.Scenario {
+		s, err := scenario.Read(strings.NewReader(`{
+  "document": {
+    "collection": "records",
+    "data": {"id": "` + id + `", "date_created": "2021-06-01T08:30:15Z"}
+  }
+}`))
+		if err != nil {
+			t.Fatalf("unable to read scenario: %v", err)
+		}
+
+		return s
+	}
+
+	c, err := scenario.Compare(context.Background(), firestore.DefaultPrices, read("aaaa"), read("bbbb"))
+	if err != nil {
+		t.Fatalf("unable to compare scenarios: %v", err)
+	}
+
+	if got := c.Attributions[1]; len(got) != 0 {
+		t.Errorf("want no attributions for same-size documents, got %+v", got)
+	}
+
+	if got := c.Estimates[1].Total() - c.Estimates[0].Total(); got != 0 {
+		t.Errorf("want no delta, got %v", got)
+	}
+}
+
+func TestCompare_TooFewScenarios(t *testing.T) {
+	_, err := scenario.Compare(context.Background(), firestore.DefaultPrices, scenario.Default())
+	if err == nil {
+		t.Error("want Compare() error for a single scenario, got nil")
+	}
+}
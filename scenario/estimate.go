@@ -0,0 +1,294 @@
+package scenario
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+// Category is a billed cost category.
+type Category string
+
+const (
+	CategoryNetwork Category = "Network"
+	CategoryWrite   Category = "Write"
+	CategoryRead    Category = "Read"
+	CategoryDelete  Category = "Delete"
+	CategoryStorage Category = "Storage"
+	CategoryBackup  Category = "Backup"
+	CategoryRestore Category = "Restore"
+	CategoryPITR    Category = "PITR"
+	CategoryExport  Category = "Export"
+)
+
+// Categories lists every category in report order.
+var Categories = []Category{
+	CategoryNetwork,
+	CategoryWrite,
+	CategoryRead,
+	CategoryDelete,
+	CategoryStorage,
+	CategoryBackup,
+	CategoryRestore,
+	CategoryPITR,
+	CategoryExport,
+}
+
+// ParseCategory returns the category named name in any case.
+func ParseCategory(name string) (Category, bool) {
+	for _, c := range Categories {
+		if strings.EqualFold(string(c), name) {
+			return c, true
+		}
+	}
+
+	return "", false
+}
+
+// Number of the categories every estimate has, the others are only estimated
+// when they are set up.
+const numCoreCategories = 5
+
+// Used returns the categories every estimate has and the ones any of the
+// estimates has, in report order.
+func Used(estimates ...Estimate) []Category {
+	used := append([]Category{}, Categories[:numCoreCategories]...)
+	for _, c := range Categories[numCoreCategories:] {
+		for _, e := range estimates {
+			if _, ok := e[c]; ok {
+				used = append(used, c)
+				break
+			}
+		}
+	}
+
+	return used
+}
+
+// Estimate is the monthly cost of each category.
+type Estimate map[Category]firestore.Money
+
+// Total returns the sum of every category.
+func (e Estimate) Total() (total firestore.Money) {
+	for _, c := range e {
+		total += c
+	}
+
+	return total
+}
+
+// Estimate calculates the monthly cost of every category of s using the
+// unit prices in p.
+func (s *Scenario) Estimate(ctx context.Context, p firestore.PriceList) (Estimate, error) {
+	return s.estimate(ctx, p, true)
+}
+
+// Calculate the monthly cost of every category of s, with the free quota
+// when free is set.
+func (s *Scenario) estimate(ctx context.Context, p firestore.PriceList, free bool) (Estimate, error) {
+	data, err := json.Marshal(s.Document.Data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal data: %w", err)
+	}
+
+	txn := big.NewInt(s.Population * s.Count)
+
+	type calculator interface {
+		Calculate(context.Context, *big.Int) (firestore.Money, error)
+	}
+
+	items := []struct {
+		category Category
+		calc     calculator
+		count    *big.Int
+	}{
+		{
+			CategoryNetwork,
+			&firestore.MonthlyNetworkingCalculator{
+				D: &firestore.DailyNetworkingCalculator{
+					Document: data,
+					Size:     s.DocumentSize,
+				},
+				Price:      p.Ingress,
+				NoFreeTier: !free,
+			},
+			txn,
+		},
+		{
+			CategoryWrite,
+			&firestore.MonthlyWriteCalculator{
+				D: &firestore.DailyWriteCalculator{Price: p.Write, NoFreeTier: !free},
+			},
+			scale(txn, s.Writes),
+		},
+		{
+			CategoryRead,
+			&firestore.MonthlyReadCalculator{
+				D: &firestore.DailyReadCalculator{Price: p.Read, NoFreeTier: !free},
+			},
+			scale(txn, s.Reads),
+		},
+		{
+			CategoryDelete,
+			&firestore.MonthlyDeleteCalculator{
+				D: &firestore.DailyDeleteCalculator{Price: p.Delete, NoFreeTier: !free},
+			},
+			s.deletes(txn, firestore.MonthNumOfDays),
+		},
+		{
+			CategoryStorage,
+			&firestore.MonthlyStorageCalculator{
+				D: &firestore.DailyStorageCalculator{
+					Document: s.document(),
+					Size:     s.DocumentSize,
+				},
+				Price:      p.Storage,
+				NoFreeTier: !free,
+			},
+			retained(txn, s.TTLDays),
+		},
+	}
+
+	if b := s.Backup; b != nil {
+		storage := &firestore.MonthlyStorageCalculator{
+			D: &firestore.DailyStorageCalculator{
+				Document: s.document(),
+				Size:     s.DocumentSize,
+			},
+		}
+
+		items = append(items, []struct {
+			category Category
+			calc     calculator
+			count    *big.Int
+		}{
+			{
+				CategoryBackup,
+				&firestore.MonthlyBackupCalculator{
+					S:             storage,
+					RetentionDays: b.RetentionDays,
+					IntervalDays:  b.IntervalDays,
+					Price:         p.Backup,
+				},
+				retained(txn, s.TTLDays),
+			},
+			{
+				CategoryRestore,
+				&firestore.MonthlyRestoreCalculator{
+					S:        storage,
+					Restores: b.Restores,
+					Price:    p.Restore,
+				},
+				retained(txn, s.TTLDays),
+			},
+			{
+				CategoryPITR,
+				&firestore.MonthlyPITRCalculator{
+					D:     storage.D,
+					Price: p.PITR,
+				},
+				b.pitrWrites(scale(txn, s.Writes)),
+			},
+		}...)
+	}
+
+	if x := s.Export; x != nil {
+		// Every export reads and every import writes the stored documents.
+		stored := new(big.Int).Mul(retained(txn, s.TTLDays), big.NewInt(firestore.MonthNumOfDays))
+
+		items = append(items, []struct {
+			category Category
+			calc     calculator
+			count    *big.Int
+		}{
+			{
+				CategoryRead,
+				&firestore.MonthlyExportCalculator{
+					D:            &firestore.DailyReadCalculator{Price: p.Read, NoFreeTier: !free},
+					IntervalDays: x.IntervalDays,
+					DailyReads:   scale(txn, s.Reads),
+				},
+				stored,
+			},
+			{
+				CategoryWrite,
+				&firestore.MonthlyImportCalculator{
+					D:           &firestore.DailyWriteCalculator{Price: p.Write, NoFreeTier: !free},
+					Imports:     x.Imports,
+					DailyWrites: scale(txn, s.Writes),
+				},
+				stored,
+			},
+			{
+				CategoryExport,
+				&firestore.MonthlyExportStorageCalculator{
+					Document:      s.document(),
+					Size:          s.DocumentSize,
+					RetentionDays: x.RetentionDays,
+					IntervalDays:  x.IntervalDays,
+					Price:         p.ExportStorage,
+				},
+				stored,
+			},
+		}...)
+	}
+
+	e := Estimate{}
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		cost, err := item.calc.Calculate(ctx, new(big.Int).Set(item.count))
+		if err != nil {
+			return nil, fmt.Errorf("unable to calculate %s cost: %w", item.category, err)
+		}
+
+		e[item.category] += cost
+	}
+
+	return e, nil
+}
+
+// Scale the documents stored in a month down to the ones a TTL of days keeps.
+func retained(n *big.Int, days int64) *big.Int {
+	return retainedIn(n, days, firestore.MonthNumOfDays)
+}
+
+// Scale the documents stored in a month of month days down to the ones a TTL
+// of days keeps.
+func retainedIn(n *big.Int, days, month int64) *big.Int {
+	if days <= 0 || days >= month {
+		return n
+	}
+
+	return scale(n, float64(days)/float64(month))
+}
+
+// Daily deletes of s, with the documents its TTL policy deletes in a month
+// of month days.
+func (s *Scenario) deletes(txn *big.Int, month int64) *big.Int {
+	expired := new(big.Int).Sub(txn, retainedIn(txn, s.TTLDays, month))
+
+	return expired.Add(expired, scale(txn, s.Deletes))
+}
+
+// Multiply n by ratio, rounded to the nearest integer.
+func scale(n *big.Int, ratio float64) *big.Int {
+	r := new(big.Rat).SetFloat64(ratio)
+	if r == nil {
+		return new(big.Int)
+	}
+	r.Mul(r, new(big.Rat).SetInt(n))
+
+	q, m := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if m.Lsh(m, 1).CmpAbs(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(r.Num().Sign())))
+	}
+
+	return q
+}
@@ -0,0 +1,174 @@
+package scenario_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+)
+
+func TestScenario_Estimate(t *testing.T) {
+	s := scenario.Default()
+
+	e, err := s.Estimate(context.Background(), firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate scenario: %v", err)
+	}
+
+	tt := []struct {
+		category scenario.Category
+		want     firestore.Money
+	}{
+		// (10,000,000 - 20,000) / 100,000 * 0.18 * 30 = 538.92, rounded once
+		// for the month.
+		{scenario.CategoryWrite, 53892 * firestore.Cent},
+		{scenario.CategoryRead, 17910 * firestore.Cent},
+		{scenario.CategoryDelete, 5988 * firestore.Cent},
+		{scenario.CategoryStorage, 7098 * firestore.Cent},
+	}
+
+	for _, tc := range tt {
+		if got := e[tc.category]; tc.want != got {
+			t.Errorf("want %v cost %v, got %v", tc.category, tc.want, got)
+		}
+	}
+}
+
+func TestScenario_Estimate_FreePrices(t *testing.T) {
+	s := scenario.Default()
+	s.Backup = &scenario.Backup{RetentionDays: 7, IntervalDays: 1, Restores: 1, PITR: true}
+
+	// Zero prices bill nothing in every category.
+	e, err := s.Estimate(context.Background(), firestore.PriceList{})
+	if err != nil {
+		t.Fatalf("unable to estimate scenario: %v", err)
+	}
+
+	for c, cost := range e {
+		if cost != 0 {
+			t.Errorf("want free %v, got %v", c, cost)
+		}
+	}
+}
+
+func TestScenario_Estimate_Ratios(t *testing.T) {
+	s := scenario.Default()
+	s.Reads = 2.5
+
+	e, err := s.Estimate(context.Background(), firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate scenario: %v", err)
+	}
+
+	// (25,000,000 - 50,000) / 100,000 * 0.06 = 14.97 * 30
+	want := 1497 * firestore.Cent * 30
+
+	if got := e[scenario.CategoryRead]; want != got {
+		t.Errorf("want read cost %v, got %v", want, got)
+	}
+}
+
+func TestScenario_Estimate_IDStrategy(t *testing.T) {
+	storage := func(id firestore.IDStrategy) firestore.Money {
+		s := scenario.Default()
+		s.IDStrategy = id
+
+		e, err := s.Estimate(context.Background(), firestore.DefaultPrices)
+		if err != nil {
+			t.Fatalf("unable to estimate scenario: %v", err)
+		}
+
+		return e[scenario.CategoryStorage]
+	}
+
+	// The default document has UUID IDs.
+	if want, got := storage(""), storage(firestore.IDUUID); want != got {
+		t.Errorf("want UUID storage cost %v, got %v", want, got)
+	}
+
+	if auto, uuid := storage(firestore.IDAuto), storage(firestore.IDUUID); auto >= uuid {
+		t.Errorf("want auto-ID storage cost %v below UUID %v", auto, uuid)
+	}
+}
+
+func TestScenario_Estimate_Backup(t *testing.T) {
+	s := scenario.Default()
+	s.DocumentSize = 1000
+
+	e, err := s.Estimate(context.Background(), firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate scenario: %v", err)
+	}
+
+	if got := scenario.Used(e); len(got) != 5 {
+		t.Errorf("want backup categories left out without backups, got %v", got)
+	}
+
+	s.Backup = &scenario.Backup{RetentionDays: 7, IntervalDays: 1, Restores: 1, PITR: true}
+
+	e, err = s.Estimate(context.Background(), firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate scenario: %v", err)
+	}
+
+	// The database stores 10,000,000 * 1,000 * 30 bytes = 300GB and writes
+	// 10GB a day.
+	tt := []struct {
+		category scenario.Category
+		want     firestore.Money
+	}{
+		// 300GB * 7 daily backups * 0.038
+		{scenario.CategoryBackup, 7980 * firestore.Cent},
+		// 300GB * 0.228
+		{scenario.CategoryRestore, 6840 * firestore.Cent},
+		// 10GB * 7 days * 0.18
+		{scenario.CategoryPITR, 1260 * firestore.Cent},
+	}
+
+	for _, tc := range tt {
+		if got := e[tc.category]; tc.want != got {
+			t.Errorf("want %v cost %v, got %v", tc.category, tc.want, got)
+		}
+	}
+
+	if got := scenario.Used(e); len(got) != 8 {
+		t.Errorf("want the backup categories, got %v", got)
+	}
+}
+
+func TestScenario_Estimate_Export(t *testing.T) {
+	s := scenario.Default()
+	s.DocumentSize = 1000
+
+	base, err := s.Estimate(context.Background(), firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate scenario: %v", err)
+	}
+
+	s.Export = &scenario.Export{IntervalDays: 7, RetentionDays: 14, Imports: 1}
+
+	e, err := s.Estimate(context.Background(), firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate scenario: %v", err)
+	}
+
+	// The database stores 300,000,000 documents of 1,000 bytes.
+	tt := []struct {
+		category scenario.Category
+		want     firestore.Money
+	}{
+		// 300,000,000 / 100,000 * 0.06 * 5 weekly exports
+		{scenario.CategoryRead, 90000 * firestore.Cent},
+		// 300,000,000 / 100,000 * 0.18
+		{scenario.CategoryWrite, 54000 * firestore.Cent},
+		// 300GB * 2 kept exports * 0.026
+		{scenario.CategoryExport, 1560 * firestore.Cent},
+	}
+
+	for _, tc := range tt {
+		if got := e[tc.category] - base[tc.category]; tc.want != got {
+			t.Errorf("want %v cost increase %v, got %v", tc.category, tc.want, got)
+		}
+	}
+}
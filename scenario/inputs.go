@@ -0,0 +1,201 @@
+package scenario
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+// Names of the numeric inputs that can be varied.
+const (
+	InputDocumentSize = "document_size"
+	InputIndexes      = "indexes"
+)
+
+// MaxIndexes is the composite index limit of a database.
+const MaxIndexes = 1000
+
+// Input is a numeric scenario input that can be varied.
+type Input struct {
+	// Name of the input.
+	Name string
+
+	// Integer tells whether values are rounded to whole numbers.
+	Integer bool
+
+	// Min is the smallest valid value.
+	Min float64
+
+	// Max is the largest valid value, zero when there is no limit.
+	Max float64
+
+	get func(s *Scenario) float64
+	set func(s *Scenario, v float64) error
+}
+
+// Get returns the value of the input in s.
+func (in Input) Get(s *Scenario) float64 {
+	return in.get(s)
+}
+
+// With returns a copy of s with the input set to v.
+func (in Input) With(s *Scenario, v float64) (*Scenario, error) {
+	if v < 0 || v < in.Min || math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil, fmt.Errorf("invalid %s value %v", in.Name, v)
+	}
+
+	if in.Max != 0 && v > in.Max {
+		return nil, fmt.Errorf("%s value %v is over the maximum %v", in.Name, v, in.Max)
+	}
+
+	// Whole-number inputs are stored as int64.
+	if in.Integer && v >= math.MaxInt64 {
+		return nil, fmt.Errorf("%s value %v is too large", in.Name, v)
+	}
+
+	if in.Integer {
+		v = math.Round(v)
+	}
+
+	c := *s
+	if err := in.set(&c, v); err != nil {
+		return nil, err
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s value %v: %w", in.Name, v, err)
+	}
+
+	return &c, nil
+}
+
+var inputs = map[string]Input{
+	InputPopulation: {
+		Name:    InputPopulation,
+		Integer: true,
+		get:     func(s *Scenario) float64 { return float64(s.Population) },
+		set: func(s *Scenario, v float64) error {
+			s.Population = int64(v)
+			return nil
+		},
+	},
+	InputCount: {
+		Name:    InputCount,
+		Integer: true,
+		get:     func(s *Scenario) float64 { return float64(s.Count) },
+		set: func(s *Scenario, v float64) error {
+			s.Count = int64(v)
+			return nil
+		},
+	},
+	InputWrites: {
+		Name: InputWrites,
+		get:  func(s *Scenario) float64 { return s.Writes },
+		set: func(s *Scenario, v float64) error {
+			s.Writes = v
+			return nil
+		},
+	},
+	InputReads: {
+		Name: InputReads,
+		get:  func(s *Scenario) float64 { return s.Reads },
+		set: func(s *Scenario, v float64) error {
+			s.Reads = v
+			return nil
+		},
+	},
+	InputDeletes: {
+		Name: InputDeletes,
+		get:  func(s *Scenario) float64 { return s.Deletes },
+		set: func(s *Scenario, v float64) error {
+			s.Deletes = v
+			return nil
+		},
+	},
+	InputDocumentSize: {
+		Name:    InputDocumentSize,
+		Integer: true,
+		Min:     1,
+		get: func(s *Scenario) float64 {
+			if s.DocumentSize != 0 {
+				return float64(s.DocumentSize)
+			}
+			return float64(s.document().Size())
+		},
+		set: func(s *Scenario, v float64) error {
+			// Keep the document model in charge of its size when it agrees.
+			s.DocumentSize = int64(v)
+			if s.DocumentSize == s.document().Size() {
+				s.DocumentSize = 0
+			}
+			return nil
+		},
+	},
+	InputIndexes: {
+		Name:    InputIndexes,
+		Integer: true,
+		Max:     MaxIndexes,
+		get:     func(s *Scenario) float64 { return float64(len(s.Document.CompositeIndexes)) },
+		set: func(s *Scenario, v float64) error {
+			// The indexes are part of the stored size that document_size
+			// replaces, so they would not change the estimate.
+			if s.DocumentSize != 0 {
+				return fmt.Errorf("%s cannot vary with document_size set", InputIndexes)
+			}
+
+			doc, err := withIndexes(s.Document, int(v))
+			if err != nil {
+				return err
+			}
+
+			s.Document = doc
+			return nil
+		},
+	},
+}
+
+// LookupInput returns the input named name.
+func LookupInput(name string) (Input, error) {
+	in, ok := inputs[name]
+	if !ok {
+		return Input{}, fmt.Errorf("unknown input %q, want one of %v", name, InputNames())
+	}
+
+	return in, nil
+}
+
+// InputNames returns the names of every numeric input.
+func InputNames() []string {
+	var names []string
+	for name := range inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Return a copy of doc with n composite indexes. Extra indexes repeat the
+// existing ones, or index single fields of the data when there are none.
+func withIndexes(doc *firestore.Document, n int) (*firestore.Document, error) {
+	pool := doc.CompositeIndexes
+	if len(pool) == 0 {
+		for _, k := range sortedKeys(doc.Data) {
+			pool = append(pool, map[string]interface{}{k: doc.Data[k]})
+		}
+	}
+
+	if n > 0 && len(pool) == 0 {
+		return nil, fmt.Errorf("document %q has no fields to index", doc.Collection)
+	}
+
+	c := *doc
+	c.CompositeIndexes = make([]map[string]interface{}, n)
+	for i := range c.CompositeIndexes {
+		c.CompositeIndexes[i] = pool[i%len(pool)]
+	}
+
+	return &c, nil
+}
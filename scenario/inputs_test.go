@@ -0,0 +1,138 @@
+package scenario_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+)
+
+func TestInput_With(t *testing.T) {
+	base := scenario.Default()
+
+	tt := []struct {
+		input string
+		value float64
+		want  float64
+	}{
+		{scenario.InputPopulation, 2500.4, 2500},
+		{scenario.InputCount, 20, 20},
+		{scenario.InputReads, 2.5, 2.5},
+		{scenario.InputDocumentSize, 4096, 4096},
+		{scenario.InputIndexes, 5, 5},
+		{scenario.InputIndexes, 0, 0},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.input, func(t *testing.T) {
+			in, err := scenario.LookupInput(tc.input)
+			if err != nil {
+				t.Fatalf("unable to lookup input: %v", err)
+			}
+
+			s, err := in.With(base, tc.value)
+			if err != nil {
+				t.Fatalf("unable to set input: %v", err)
+			}
+
+			if got := in.Get(s); tc.want != got {
+				t.Errorf("want %v = %v, got %v", tc.input, tc.want, got)
+			}
+		})
+	}
+
+	if got := len(base.Document.CompositeIndexes); got != 3 {
+		t.Errorf("want base scenario unchanged with 3 indexes, got %v", got)
+	}
+}
+
+func TestLookupInput_Unknown(t *testing.T) {
+	if _, err := scenario.LookupInput("egress"); err == nil {
+		t.Error("want LookupInput() error for unknown input, got nil")
+	}
+}
+
+func TestInput_WithIndexesAndDocumentSize(t *testing.T) {
+	base := scenario.Default()
+	base.DocumentSize = 4096
+
+	in, err := scenario.LookupInput(scenario.InputIndexes)
+	if err != nil {
+		t.Fatalf("unable to lookup input: %v", err)
+	}
+
+	if _, err := in.With(base, 5); err == nil {
+		t.Error("want With() error for indexes with document_size set, got nil")
+	}
+}
+
+func TestInput_WithDocumentSizeIngress(t *testing.T) {
+	in, err := scenario.LookupInput(scenario.InputDocumentSize)
+	if err != nil {
+		t.Fatalf("unable to lookup input: %v", err)
+	}
+
+	small, err := in.With(scenario.Default(), 1000)
+	if err != nil {
+		t.Fatalf("unable to set input: %v", err)
+	}
+
+	large, err := in.With(scenario.Default(), 4000)
+	if err != nil {
+		t.Fatalf("unable to set input: %v", err)
+	}
+
+	ctx := context.Background()
+
+	se, err := small.Estimate(ctx, firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate: %v", err)
+	}
+
+	le, err := large.Estimate(ctx, firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate: %v", err)
+	}
+
+	if se[scenario.CategoryNetwork] >= le[scenario.CategoryNetwork] {
+		t.Errorf(
+			"want ingress to grow with document_size, got %v and %v",
+			se[scenario.CategoryNetwork],
+			le[scenario.CategoryNetwork],
+		)
+	}
+}
+
+func TestInput_WithTooLarge(t *testing.T) {
+	tt := []struct {
+		input string
+		value float64
+	}{
+		// Population times count overflows int64.
+		{scenario.InputPopulation, 1e17},
+		// Larger than any int64.
+		{scenario.InputPopulation, 1e19},
+		{scenario.InputCount, 1e19},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.input, func(t *testing.T) {
+			in, err := scenario.LookupInput(tc.input)
+			if err != nil {
+				t.Fatalf("unable to lookup input: %v", err)
+			}
+
+			base := scenario.Default()
+			base.Count = 100
+
+			if _, err := in.With(base, tc.value); err == nil {
+				t.Errorf("want With() error for %v, got nil", tc.value)
+			}
+		})
+	}
+}
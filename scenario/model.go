@@ -0,0 +1,684 @@
+package scenario
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+// Model is a data model of many collections stored in one database.
+type Model struct {
+	// Name identifies the model in reports.
+	Name string `json:"name"`
+
+	// IDStrategy generates the document IDs of the collections that have
+	// none, literal IDs are kept when it is empty.
+	IDStrategy firestore.IDStrategy `json:"id_strategy,omitempty"`
+
+	// Backup is the backup and recovery setup of the database, nil when
+	// there is none.
+	Backup *Backup `json:"backup,omitempty"`
+
+	// Export is the managed export and import schedule of the database, nil
+	// when there is none.
+	Export *Export `json:"export,omitempty"`
+
+	// Collections are the collections of the database.
+	Collections []*Collection `json:"collections"`
+}
+
+// Collection is a collection with its own document shape and traffic.
+type Collection struct {
+	// Name identifies the collection in reports, the collection path of the
+	// document when empty.
+	Name string `json:"name,omitempty"`
+
+	// Path is the document path template of the collection, e.g.
+	// profiles/{profileId}/logs/{logId}. The ID and collection of Document
+	// are sized from it when set.
+	Path string `json:"path,omitempty"`
+
+	// Document is the stored document model, optional with a Path.
+	Document *firestore.Document `json:"document"`
+
+	// DocumentSize overrides the stored and transferred size of Document in
+	// bytes when set.
+	DocumentSize int64 `json:"document_size,omitempty"`
+
+	// IDStrategy generates the document IDs of the collection, overriding
+	// the strategy of the model. Placeholders of the path template with a
+	// strategy keep theirs.
+	IDStrategy firestore.IDStrategy `json:"id_strategy,omitempty"`
+
+	// Documents is the number of documents stored at the start of the month.
+	Documents int64 `json:"documents"`
+
+	// DailyGrowth is the number of documents added every day.
+	DailyGrowth int64 `json:"daily_growth,omitempty"`
+
+	// Writes is the number of daily document writes.
+	Writes int64 `json:"writes"`
+
+	// Reads is the number of daily document reads.
+	Reads int64 `json:"reads"`
+
+	// Deletes is the number of daily document deletes.
+	Deletes int64 `json:"deletes"`
+
+	// RecursiveDeletes is the number of documents deleted daily with every
+	// document of their subcollections.
+	RecursiveDeletes int64 `json:"recursive_deletes,omitempty"`
+
+	// FanOut is the number of documents stored per parent document. It
+	// derives Documents and DailyGrowth from the parent collection.
+	FanOut float64 `json:"fan_out,omitempty"`
+
+	// MonthlyFanOut is the number of documents added per parent document
+	// every month. It derives DailyGrowth from the parent collection.
+	MonthlyFanOut float64 `json:"monthly_fan_out,omitempty"`
+
+	path *Path
+}
+
+// document returns the document model of c with the ID and collection of its
+// path template, and IDs generated by the strategy of c or else def.
+func (c *Collection) document(def firestore.IDStrategy) *firestore.Document {
+	if c.IDStrategy != "" {
+		def = c.IDStrategy
+	}
+
+	if c.path == nil {
+		if def == "" {
+			return c.Document
+		}
+		return c.Document.WithIDs(def)
+	}
+
+	doc := &firestore.Document{}
+	if c.Document != nil {
+		*doc = *c.Document
+	}
+	doc.ID, doc.Collection = c.path.Document(def)
+
+	return doc
+}
+
+// WithIDs returns a copy of m with every document ID generated by id, except
+// for the placeholders of path templates that have their own strategy.
+func (m *Model) WithIDs(id firestore.IDStrategy) *Model {
+	c := *m
+	c.IDStrategy = id
+
+	c.Collections = make([]*Collection, len(m.Collections))
+	for i, coll := range m.Collections {
+		cc := *coll
+		cc.IDStrategy = ""
+		c.Collections[i] = &cc
+	}
+
+	return &c
+}
+
+// size returns the stored size of a document of c in bytes.
+func (c *Collection) size(def firestore.IDStrategy) int64 {
+	if c.DocumentSize != 0 {
+		return c.DocumentSize
+	}
+
+	return c.document(def).Size()
+}
+
+// ReadModel decodes and validates a JSON data model with the timestamp rules
+// of Read.
+func ReadModel(r io.Reader) (*Model, error) {
+	m := &Model{}
+
+	if err := json.NewDecoder(r).Decode(m); err != nil {
+		return nil, fmt.Errorf("unable to decode model: %w", err)
+	}
+
+	for _, c := range m.Collections {
+		if c != nil && c.Document != nil {
+			normalize(c.Document)
+		}
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid model: %w", err)
+	}
+
+	return m, nil
+}
+
+// LoadModel reads a JSON data model file.
+func LoadModel(path string) (*Model, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open model: %w", err)
+	}
+	defer f.Close()
+
+	m, err := ReadModel(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return m, nil
+}
+
+// Validate reports the first invalid input of m.
+func (m *Model) Validate() error {
+	if len(m.Collections) == 0 {
+		return fmt.Errorf("collections are required")
+	}
+
+	if m.IDStrategy != "" {
+		id, err := firestore.ParseIDStrategy(string(m.IDStrategy))
+		if err != nil {
+			return err
+		}
+		m.IDStrategy = id
+	}
+
+	if m.Backup != nil {
+		if err := m.Backup.validate(); err != nil {
+			return err
+		}
+	}
+
+	if m.Export != nil {
+		if err := m.Export.validate(); err != nil {
+			return err
+		}
+	}
+
+	names := map[string]bool{}
+	for i, c := range m.Collections {
+		if c == nil {
+			return fmt.Errorf("collection %d is empty", i+1)
+		}
+		if err := c.validate(); err != nil {
+			return fmt.Errorf("collection %d: %w", i+1, err)
+		}
+		if names[c.Name] {
+			return fmt.Errorf("collection %q is defined twice", c.Name)
+		}
+		names[c.Name] = true
+	}
+
+	for _, c := range m.Collections {
+		if c.FanOut == 0 && c.MonthlyFanOut == 0 {
+			continue
+		}
+
+		switch {
+		case c.path == nil:
+			return fmt.Errorf("collection %q: fan-out requires a path", c.Name)
+		case m.parent(c) < 0:
+			return fmt.Errorf("collection %q: no collection of parent %s", c.Name, c.path.Parent())
+		case c.FanOut > 0 && (c.Documents != 0 || c.DailyGrowth != 0):
+			return fmt.Errorf("collection %q: documents are derived from fan_out", c.Name)
+		case c.MonthlyFanOut > 0 && c.DailyGrowth != 0:
+			return fmt.Errorf("collection %q: daily_growth is derived from monthly_fan_out", c.Name)
+		}
+	}
+
+	return nil
+}
+
+// Return the index of the collection of the parent documents of c, or -1 when
+// there is none.
+func (m *Model) parent(c *Collection) int {
+	if c.path == nil || c.path.Parent() == nil {
+		return -1
+	}
+
+	for i, p := range m.Collections {
+		if p.path != nil && p.path.Match(c.path.Parent()) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Report the first invalid input of c, and parse its path template.
+func (c *Collection) validate() error {
+	if c.Path != "" {
+		path, err := ParsePath(c.Path)
+		if err != nil {
+			return err
+		}
+		c.path = path
+	}
+
+	if c.IDStrategy != "" {
+		id, err := firestore.ParseIDStrategy(string(c.IDStrategy))
+		if err != nil {
+			return err
+		}
+		c.IDStrategy = id
+	}
+
+	if c.Name == "" {
+		switch {
+		case c.path != nil:
+			c.Name = c.path.Collection()
+		case c.Document != nil:
+			c.Name = c.Document.Collection
+		}
+	}
+
+	switch {
+	case c.path != nil:
+	case c.Document == nil:
+		return fmt.Errorf("document is required")
+	case c.Document.Collection == "":
+		return fmt.Errorf("document collection is required")
+	case strings.Count(c.Document.Collection, "/")%2 != 0:
+		return fmt.Errorf("%q is not a collection path", c.Document.Collection)
+	}
+
+	switch {
+	case c.DocumentSize < 0:
+		return fmt.Errorf("document_size %d is negative", c.DocumentSize)
+	case c.Documents < 0 || c.DailyGrowth < 0:
+		return fmt.Errorf("document counts must not be negative")
+	case c.Writes < 0 || c.Reads < 0 || c.Deletes < 0 || c.RecursiveDeletes < 0:
+		return fmt.Errorf("daily operations must not be negative")
+	case c.FanOut < 0 || c.MonthlyFanOut < 0:
+		return fmt.Errorf("fan-out must not be negative")
+	}
+
+	return nil
+}
+
+// count is the number of stored documents of a collection.
+type count struct {
+	documents int64
+	growth    int64
+}
+
+// Return the average number of documents stored over the month.
+func (n count) average() float64 {
+	return float64(n.documents) + float64(n.growth)*(firestore.MonthNumOfDays+1)/2
+}
+
+// Resolve the stored documents and daily growth of every collection, deriving
+// them level by level from the counts of the parent collection for fan-outs.
+func (m *Model) counts() []count {
+	counts := make([]*count, len(m.Collections))
+
+	var resolve func(i int) count
+	resolve = func(i int) count {
+		if counts[i] != nil {
+			return *counts[i]
+		}
+
+		c := m.Collections[i]
+		n := count{c.Documents, c.DailyGrowth}
+
+		if j := m.parent(c); j >= 0 && (c.FanOut > 0 || c.MonthlyFanOut > 0) {
+			p := resolve(j)
+
+			if c.FanOut > 0 {
+				n.documents = scale(big.NewInt(p.documents), c.FanOut).Int64()
+				n.growth = scale(big.NewInt(p.growth), c.FanOut).Int64()
+			}
+
+			monthly := scale(big.NewInt(p.documents), c.MonthlyFanOut/firestore.MonthNumOfDays)
+			n.growth += monthly.Int64()
+		}
+
+		counts[i] = &n
+		return n
+	}
+
+	result := make([]count, len(m.Collections))
+	for i := range m.Collections {
+		result[i] = resolve(i)
+	}
+
+	return result
+}
+
+// RecursiveDelete returns the subcollections deleted with the documents of the
+// collection name, with fan-outs of their fan_out or else of the ratio of
+// their average documents to the average parent documents over the month.
+func (m *Model) RecursiveDelete(name string) (*firestore.RecursiveDelete, error) {
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid model: %w", err)
+	}
+
+	i := m.index(name)
+	if i < 0 {
+		return nil, fmt.Errorf("unknown collection %q", name)
+	}
+
+	return m.recursiveDelete(i, m.counts()), nil
+}
+
+// Return the subcollections of the documents of collection i.
+func (m *Model) recursiveDelete(i int, counts []count) *firestore.RecursiveDelete {
+	var subcollections func(i int) []*firestore.Subcollection
+	subcollections = func(i int) []*firestore.Subcollection {
+		var subs []*firestore.Subcollection
+		for j, c := range m.Collections {
+			if j == i || m.parent(c) != i {
+				continue
+			}
+
+			fanOut := c.FanOut
+			if parents := counts[i].average(); fanOut == 0 && parents > 0 {
+				fanOut = counts[j].average() / parents
+			}
+
+			subs = append(subs, &firestore.Subcollection{
+				Name:           c.Name,
+				FanOut:         fanOut,
+				Subcollections: subcollections(j),
+			})
+		}
+
+		return subs
+	}
+
+	return &firestore.RecursiveDelete{Subcollections: subcollections(i)}
+}
+
+// Return the index of the collection name, or -1 when there is none.
+func (m *Model) index(name string) int {
+	for i, c := range m.Collections {
+		if c.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// ModelEstimate is the monthly cost of a data model.
+type ModelEstimate struct {
+	// Collections is the share of every collection by name.
+	Collections map[string]Estimate
+
+	// Total is the cost of the whole database.
+	Total Estimate
+}
+
+// Estimate calculates the monthly cost of every category of m using the unit
+// prices in p.
+//
+// The operations, stored bytes and ingress of every collection are added up
+// so the free tier is applied once to the whole database. The cost of each
+// category is then shared between the collections in proportion to their
+// usage, in billing units so the collection subtotals add up to the total
+// exactly.
+//
+// Storage is billed on the average number of documents stored over the
+// month, and ingress on the JSON size or document_size of every written
+// document. m is validated first, so models built in code estimate like read
+// ones.
+func (m *Model) Estimate(ctx context.Context, p firestore.PriceList) (*ModelEstimate, error) {
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid model: %w", err)
+	}
+
+	usage := map[Category][]*big.Int{}
+	counts := m.counts()
+
+	// Documents stored at the end of the month, read by every export and
+	// written by every import.
+	var exported []*big.Int
+
+	for i, c := range m.Collections {
+		data, err := json.Marshal(c.document(m.IDStrategy).Data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal %s data: %w", c.Name, err)
+		}
+
+		// Documents stored by day, summed over the days of the month.
+		docDays := new(big.Int).Add(
+			new(big.Int).Mul(big.NewInt(counts[i].documents), big.NewInt(firestore.MonthNumOfDays)),
+			new(big.Int).Mul(big.NewInt(counts[i].growth), big.NewInt(firestore.MonthNumOfDays*(firestore.MonthNumOfDays+1)/2)),
+		)
+
+		transit := int64(len(data))
+		if c.DocumentSize != 0 {
+			transit = c.DocumentSize
+		}
+
+		ingress := new(big.Int).Mul(big.NewInt(transit), big.NewInt(c.Writes))
+
+		usage[CategoryNetwork] = append(usage[CategoryNetwork], ingress)
+		usage[CategoryWrite] = append(usage[CategoryWrite], big.NewInt(c.Writes))
+		usage[CategoryRead] = append(usage[CategoryRead], big.NewInt(c.Reads))
+		usage[CategoryDelete] = append(usage[CategoryDelete], big.NewInt(c.Deletes))
+		size := big.NewInt(c.size(m.IDStrategy))
+		byteDays := docDays.Mul(docDays, size)
+
+		usage[CategoryStorage] = append(usage[CategoryStorage], byteDays)
+		usage[CategoryBackup] = append(usage[CategoryBackup], byteDays)
+		usage[CategoryRestore] = append(usage[CategoryRestore], byteDays)
+		usage[CategoryPITR] = append(usage[CategoryPITR], new(big.Int).Mul(big.NewInt(c.Writes), size))
+
+		stored := big.NewInt(counts[i].documents + counts[i].growth*firestore.MonthNumOfDays)
+		exported = append(exported, stored)
+
+		if x := m.Export; x != nil {
+			calc := &firestore.MonthlyExportStorageCalculator{
+				Document:      c.document(m.IDStrategy),
+				Size:          c.DocumentSize,
+				RetentionDays: x.RetentionDays,
+				IntervalDays:  x.IntervalDays,
+			}
+			usage[CategoryExport] = append(usage[CategoryExport], calc.Bytes(stored))
+		}
+	}
+
+	// Recursive deletes delete the documents of the subcollections, found
+	// by queries billed as reads of the subcollection documents.
+	for i, c := range m.Collections {
+		if c.RecursiveDeletes == 0 {
+			continue
+		}
+
+		rd := m.recursiveDelete(i, counts)
+		n := big.NewInt(c.RecursiveDeletes)
+
+		usage[CategoryDelete][i].Add(usage[CategoryDelete][i], n)
+		for name, docs := range rd.Cascade(n) {
+			j := m.index(name)
+			usage[CategoryDelete][j].Add(usage[CategoryDelete][j], docs)
+			usage[CategoryRead][j].Add(usage[CategoryRead][j], docs)
+		}
+
+		// Queries finding no documents are billed one read.
+		empty := new(big.Int).Sub(rd.Reads(n), rd.Descendants(n))
+		usage[CategoryRead][i].Add(usage[CategoryRead][i], empty)
+	}
+
+	categories := m.categories()
+
+	// Shares are in proportion to usage, which are the monthly operations
+	// for reads and writes with exports.
+	weights := map[Category][]*big.Int{}
+	for _, c := range categories {
+		weights[c] = usage[c]
+	}
+
+	total := Estimate{}
+	for _, category := range categories {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		cost, err := m.categoryCost(ctx, category, sum(usage[category]), p)
+		if err != nil {
+			return nil, fmt.Errorf("unable to calculate %s cost: %w", category, err)
+		}
+
+		total[category] = cost
+	}
+
+	if x := m.Export; x != nil {
+		exports := &firestore.MonthlyExportCalculator{
+			D:            &firestore.DailyReadCalculator{Price: p.Read},
+			IntervalDays: x.IntervalDays,
+			DailyReads:   sum(usage[CategoryRead]),
+		}
+		imports := &firestore.MonthlyImportCalculator{
+			D:           &firestore.DailyWriteCalculator{Price: p.Write},
+			Imports:     x.Imports,
+			DailyWrites: sum(usage[CategoryWrite]),
+		}
+
+		reads, err := exports.Calculate(ctx, sum(exported))
+		if err != nil {
+			return nil, fmt.Errorf("unable to calculate export cost: %w", err)
+		}
+
+		writes, err := imports.Calculate(ctx, sum(exported))
+		if err != nil {
+			return nil, fmt.Errorf("unable to calculate import cost: %w", err)
+		}
+
+		total[CategoryRead] += reads
+		total[CategoryWrite] += writes
+
+		weights[CategoryRead] = monthlyOps(usage[CategoryRead], exported, exports.Exports())
+		weights[CategoryWrite] = monthlyOps(usage[CategoryWrite], exported, x.Imports)
+	}
+
+	e := &ModelEstimate{Collections: map[string]Estimate{}, Total: total}
+	for _, c := range m.Collections {
+		e.Collections[c.Name] = Estimate{}
+	}
+
+	for _, category := range categories {
+		for i, share := range allocate(total[category], weights[category]) {
+			e.Collections[m.Collections[i].Name][category] = share
+		}
+	}
+
+	return e, nil
+}
+
+// Return the categories estimated for the setup of m.
+func (m *Model) categories() []Category {
+	set := Estimate{}
+	if m.Backup != nil {
+		set[CategoryBackup], set[CategoryRestore], set[CategoryPITR] = 0, 0, 0
+	}
+	if m.Export != nil {
+		set[CategoryExport] = 0
+	}
+
+	return Used(set)
+}
+
+// Return the monthly operations of daily operations and of the documents of
+// n full exports or imports.
+func monthlyOps(daily, documents []*big.Int, n int64) []*big.Int {
+	ops := make([]*big.Int, len(daily))
+	for i := range daily {
+		ops[i] = new(big.Int).Mul(daily[i], big.NewInt(firestore.MonthNumOfDays))
+		ops[i].Add(ops[i], new(big.Int).Mul(documents[i], big.NewInt(n)))
+	}
+
+	return ops
+}
+
+// Calculate the monthly cost of a category from its database usage: daily
+// operations, ingress bytes by day, stored byte-days over the month, bytes
+// written daily for point-in-time recovery, or kept export bytes.
+func (m *Model) categoryCost(ctx context.Context, c Category, n *big.Int, p firestore.PriceList) (firestore.Money, error) {
+	month := int64(firestore.OneGB * firestore.MonthNumOfDays)
+
+	switch c {
+	case CategoryNetwork:
+		monthly := new(big.Int).Mul(n, big.NewInt(firestore.MonthNumOfDays))
+		monthly.Sub(monthly, big.NewInt(firestore.MonthlyFreeIngress))
+		return firestore.Charge(monthly, p.Ingress, firestore.OneGB), nil
+	case CategoryWrite:
+		calc := &firestore.MonthlyWriteCalculator{D: &firestore.DailyWriteCalculator{Price: p.Write}}
+		return calc.Calculate(ctx, n)
+	case CategoryRead:
+		calc := &firestore.MonthlyReadCalculator{D: &firestore.DailyReadCalculator{Price: p.Read}}
+		return calc.Calculate(ctx, n)
+	case CategoryDelete:
+		calc := &firestore.MonthlyDeleteCalculator{D: &firestore.DailyDeleteCalculator{Price: p.Delete}}
+		return calc.Calculate(ctx, n)
+	case CategoryStorage:
+		free := new(big.Int).Mul(big.NewInt(firestore.MonthlyFreeStorage), big.NewInt(firestore.MonthNumOfDays))
+		return firestore.Charge(new(big.Int).Sub(n, free), p.Storage, month), nil
+	case CategoryBackup:
+		calc := &firestore.MonthlyBackupCalculator{
+			RetentionDays: m.Backup.RetentionDays,
+			IntervalDays:  m.Backup.IntervalDays,
+		}
+		return firestore.Charge(n.Mul(n, big.NewInt(calc.Backups())), p.Backup, month), nil
+	case CategoryRestore:
+		return firestore.Charge(n.Mul(n, big.NewInt(m.Backup.Restores)), p.Restore, month), nil
+	case CategoryPITR:
+		n = m.Backup.pitrWrites(n)
+		return firestore.Charge(n.Mul(n, big.NewInt(firestore.PITRWindowDays)), p.PITR, firestore.OneGB), nil
+	case CategoryExport:
+		return firestore.Charge(n, p.ExportStorage, firestore.OneGB), nil
+	}
+
+	return 0, fmt.Errorf("unknown category %q", c)
+}
+
+// Return the sum of values.
+func sum(values []*big.Int) *big.Int {
+	total := new(big.Int)
+	for _, v := range values {
+		total.Add(total, v)
+	}
+
+	return total
+}
+
+// Share cost in proportion to weights in billing units with the largest
+// remainder method, so the shares add up to cost exactly.
+func allocate(cost firestore.Money, weights []*big.Int) []firestore.Money {
+	shares := make([]firestore.Money, len(weights))
+
+	total := sum(weights)
+	if total.Sign() == 0 || cost == 0 {
+		return shares
+	}
+
+	units := int64(cost / firestore.BillingUnit)
+
+	remainders := make([]*big.Int, len(weights))
+	left := units
+	for i, w := range weights {
+		q, r := new(big.Int).QuoRem(new(big.Int).Mul(big.NewInt(units), w), total, new(big.Int))
+		shares[i] = firestore.Money(q.Int64()) * firestore.BillingUnit
+		remainders[i] = r
+		left -= q.Int64()
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]].Cmp(remainders[order[b]]) > 0
+	})
+
+	for _, i := range order[:left] {
+		shares[i] += firestore.BillingUnit
+	}
+
+	return shares
+}
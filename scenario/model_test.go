@@ -0,0 +1,428 @@
+package scenario_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+)
+
+const model = `{
+	"name": "app",
+	"collections": [
+		{
+			"document": {"id": "jeff", "collection": "users", "data": {"name": "Jeff"}},
+			"document_size": 2000,
+			"documents": 1000000,
+			"writes": 90000,
+			"reads": 10000
+		},
+		{
+			"name": "logs",
+			"document": {
+				"id": "log",
+				"collection": "profiles/jeff/logs",
+				"data": {"created": "2026-10-01T00:00:00Z"}
+			},
+			"document_size": 1000,
+			"documents": 1000000,
+			"writes": 30000,
+			"reads": 30000
+		}
+	]
+}`
+
+func TestModel_Estimate(t *testing.T) {
+	m, err := scenario.ReadModel(strings.NewReader(model))
+	if err != nil {
+		t.Fatalf("unable to read model: %v", err)
+	}
+
+	e, err := m.Estimate(context.Background(), firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate model: %v", err)
+	}
+
+	tt := []struct {
+		name     string
+		estimate scenario.Estimate
+		category scenario.Category
+		want     firestore.Money
+	}{
+		// Each collection alone is within the free writes, together
+		// (120,000 - 20,000) / 100,000 * 0.18 = 0.18 * 30 is billed.
+		{"total", e.Total, scenario.CategoryWrite, 540 * firestore.Cent},
+		{"users", e.Collections["users"], scenario.CategoryWrite, 405 * firestore.Cent},
+		{"logs", e.Collections["logs"], scenario.CategoryWrite, 135 * firestore.Cent},
+
+		// 40,000 reads are within the free reads.
+		{"total", e.Total, scenario.CategoryRead, 0},
+
+		// (3,000,000,000 - 1,073,741,824) / 1,000,000,000 * 0.18 = 0.35
+		// shared 2:1 in cents with the remainder to the larger fraction.
+		{"total", e.Total, scenario.CategoryStorage, 35 * firestore.Cent},
+		{"users", e.Collections["users"], scenario.CategoryStorage, 23 * firestore.Cent},
+		{"logs", e.Collections["logs"], scenario.CategoryStorage, 12 * firestore.Cent},
+	}
+
+	for _, tc := range tt {
+		if got := tc.estimate[tc.category]; tc.want != got {
+			t.Errorf("want %s %v cost %v, got %v", tc.name, tc.category, tc.want, got)
+		}
+	}
+
+	for _, c := range scenario.Categories {
+		var subtotal firestore.Money
+		for _, ce := range e.Collections {
+			subtotal += ce[c]
+		}
+
+		if subtotal != e.Total[c] {
+			t.Errorf("want %v subtotals to add up to %v, got %v", c, e.Total[c], subtotal)
+		}
+	}
+}
+
+func TestModel_Estimate_Growth(t *testing.T) {
+	estimate := func(documents, growth int64) firestore.Money {
+		m := &scenario.Model{Collections: []*scenario.Collection{{
+			Name:         "logs",
+			Document:     scenario.DefaultDocument(),
+			DocumentSize: 1000000,
+			Documents:    documents,
+			DailyGrowth:  growth,
+		}}}
+
+		e, err := m.Estimate(context.Background(), firestore.DefaultPrices)
+		if err != nil {
+			t.Fatalf("unable to estimate model: %v", err)
+		}
+
+		return e.Total[scenario.CategoryStorage]
+	}
+
+	// Adding 2,000 documents a day stores 31,000 documents on average.
+	if want, got := estimate(31000, 0), estimate(0, 2000); want != got {
+		t.Errorf("want storage cost %v, got %v", want, got)
+	}
+}
+
+func TestReadModel_Invalid(t *testing.T) {
+	tt := []struct {
+		name  string
+		input string
+	}{
+		{"no collections", `{"name": "app"}`},
+		{"missing document", `{"collections": [{"name": "users"}]}`},
+		{"document path", `{"collections": [{"document": {"id": "a", "collection": "users/jeff"}}]}`},
+		{"negative writes", `{"collections": [{"document": {"id": "a", "collection": "users"}, "writes": -1}]}`},
+		{"duplicate", `{"collections": [
+			{"document": {"id": "a", "collection": "users"}},
+			{"document": {"id": "b", "collection": "users"}}
+		]}`},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := scenario.ReadModel(strings.NewReader(tc.input)); err == nil {
+				t.Error("want error, got nil")
+			}
+		})
+	}
+}
+
+func TestModel_Estimate_FanOut(t *testing.T) {
+	m, err := scenario.ReadModel(strings.NewReader(`{
+		"collections": [
+			{
+				"path": "profiles/{profileId:36}",
+				"document_size": 1000000,
+				"documents": 3000,
+				"daily_growth": 10
+			},
+			{
+				"path": "profiles/{id:36}/logs/{logId}",
+				"document_size": 1000000,
+				"monthly_fan_out": 40
+			},
+			{
+				"path": "profiles/{id:36}/addresses/{addressId}",
+				"document_size": 1000000,
+				"fan_out": 2
+			}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("unable to read model: %v", err)
+	}
+
+	if got := m.Collections[1].Name; got != "profiles/{id:36}/logs" {
+		t.Errorf("want name from the path, got %v", got)
+	}
+
+	e, err := m.Estimate(context.Background(), firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate model: %v", err)
+	}
+
+	// Profiles store 3,000 * 30 + 10 * 465 document-days, logs grow by
+	// 3,000 * 40 / 30 = 4,000 documents a day for 4,000 * 465, and addresses
+	// are 6,000 growing by 20 a day for 6,000 * 30 + 20 * 465, of 1MB each.
+	// (2,143,950,000,000 - 1,073,741,824 * 30) / 30,000,000,000 * 0.18
+	// = 12.67 shared by document-days.
+	tt := []struct {
+		name string
+		want firestore.Money
+	}{
+		{"profiles", 56 * firestore.Cent},
+		{"profiles/{id:36}/logs", 1099 * firestore.Cent},
+		{"profiles/{id:36}/addresses", 112 * firestore.Cent},
+	}
+
+	for _, tc := range tt {
+		if got := e.Collections[tc.name][scenario.CategoryStorage]; got != tc.want {
+			t.Errorf("want %v storage cost %v, got %v", tc.name, tc.want, got)
+		}
+	}
+
+	if want, got := 1267*firestore.Cent, e.Total[scenario.CategoryStorage]; got != want {
+		t.Errorf("want storage cost %v, got %v", want, got)
+	}
+}
+
+func TestModel_Estimate_InCode(t *testing.T) {
+	m := &scenario.Model{Collections: []*scenario.Collection{
+		{Path: "profiles/{profileId:36}", DocumentSize: 1000000, Documents: 3000},
+		{Path: "profiles/{id:36}/addresses/{addressId}", DocumentSize: 1000000, FanOut: 2},
+	}}
+
+	e, err := m.Estimate(context.Background(), firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate model: %v", err)
+	}
+
+	// The addresses are 6,000 documents derived from the path and fan-out.
+	profiles := e.Collections["profiles"][scenario.CategoryStorage]
+	addresses := e.Collections["profiles/{id:36}/addresses"][scenario.CategoryStorage]
+	if d := addresses - 2*profiles; profiles == 0 || d < -firestore.Cent || d > firestore.Cent {
+		t.Errorf("want addresses storage twice the profiles, got %v and %v", addresses, profiles)
+	}
+
+	bad := &scenario.Model{Collections: []*scenario.Collection{
+		{Path: "profiles/{id}/addresses/{addressId}", FanOut: 2},
+	}}
+	if _, err := bad.Estimate(context.Background(), firestore.DefaultPrices); err == nil {
+		t.Error("want Estimate() error for a fan-out without parent, got nil")
+	}
+}
+
+func TestReadModel_InvalidFanOut(t *testing.T) {
+	tt := []struct {
+		name  string
+		input string
+	}{
+		{"no path", `{"collections": [{"document": {"id": "a", "collection": "users"}, "fan_out": 2}]}`},
+		{"no parent", `{"collections": [{"path": "users/{id}/logs/{logId}", "fan_out": 2}]}`},
+		{"documents", `{"collections": [
+			{"path": "users/{id}", "documents": 10},
+			{"path": "users/{id}/logs/{logId}", "fan_out": 2, "documents": 1}
+		]}`},
+		{"negative", `{"collections": [
+			{"path": "users/{id}", "documents": 10},
+			{"path": "users/{id}/logs/{logId}", "monthly_fan_out": -2}
+		]}`},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := scenario.ReadModel(strings.NewReader(tc.input)); err == nil {
+				t.Error("want error, got nil")
+			}
+		})
+	}
+}
+
+func TestModel_WithIDs(t *testing.T) {
+	read := func(input string) *scenario.Model {
+		m, err := scenario.ReadModel(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("unable to read model: %v", err)
+		}
+
+		return m
+	}
+
+	storage := func(m *scenario.Model) firestore.Money {
+		e, err := m.Estimate(context.Background(), firestore.DefaultPrices)
+		if err != nil {
+			t.Fatalf("unable to estimate model: %v", err)
+		}
+
+		return e.Total[scenario.CategoryStorage]
+	}
+
+	users := read(`{
+		"id_strategy": "uuid",
+		"collections": [{"path": "users/{userId}", "documents": 100000000, "id_strategy": "ulid"}]
+	}`)
+
+	auto := users.WithIDs(firestore.IDAuto)
+	if got := auto.Collections[0].IDStrategy; got != "" {
+		t.Errorf("want collection strategy cleared, got %v", got)
+	}
+	if users.Collections[0].IDStrategy != firestore.IDULID {
+		t.Error("want the model unchanged")
+	}
+
+	if a, b := storage(auto), storage(users.WithIDs(firestore.IDUUID)); a >= b {
+		t.Errorf("want auto-ID storage cost %v below UUID %v", a, b)
+	}
+
+	// Placeholders with a strategy keep it.
+	orders := read(`{"collections": [{"path": "orders/{orderId:numeric}", "documents": 100000000}]}`)
+	if a, b := storage(orders.WithIDs(firestore.IDAuto)), storage(orders.WithIDs(firestore.IDUUID)); a != b {
+		t.Errorf("want order storage cost %v, got %v", a, b)
+	}
+}
+
+func TestModel_Estimate_Backup(t *testing.T) {
+	m, err := scenario.ReadModel(strings.NewReader(`{
+		"backup": {"retention_days": 14, "interval_days": 7, "restores": 1, "pitr": true},
+		"collections": [
+			{"path": "users/{userId}", "document_size": 1000, "documents": 100000000, "writes": 1000000},
+			{"path": "logs/{logId}", "document_size": 1000, "documents": 200000000, "writes": 3000000}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("unable to read model: %v", err)
+	}
+
+	e, err := m.Estimate(context.Background(), firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate model: %v", err)
+	}
+
+	// The database stores 300GB and writes 4GB a day.
+	tt := []struct {
+		name     string
+		estimate scenario.Estimate
+		category scenario.Category
+		want     firestore.Money
+	}{
+		// 300GB * 2 weekly backups * 0.038
+		{"total", e.Total, scenario.CategoryBackup, 2280 * firestore.Cent},
+		{"users", e.Collections["users"], scenario.CategoryBackup, 760 * firestore.Cent},
+		// 300GB * 0.228
+		{"total", e.Total, scenario.CategoryRestore, 6840 * firestore.Cent},
+		// 4GB * 7 days * 0.18
+		{"total", e.Total, scenario.CategoryPITR, 504 * firestore.Cent},
+		{"logs", e.Collections["logs"], scenario.CategoryPITR, 378 * firestore.Cent},
+	}
+
+	for _, tc := range tt {
+		if got := tc.estimate[tc.category]; tc.want != got {
+			t.Errorf("want %s %v cost %v, got %v", tc.name, tc.category, tc.want, got)
+		}
+	}
+}
+
+func TestModel_Estimate_Export(t *testing.T) {
+	m, err := scenario.ReadModel(strings.NewReader(`{
+		"export": {"interval_days": 1, "retention_days": 7, "imports": 1},
+		"collections": [
+			{"path": "users/{userId}", "document_size": 1000, "documents": 10000000},
+			{"path": "logs/{logId}", "document_size": 1000, "documents": 30000000}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("unable to read model: %v", err)
+	}
+
+	e, err := m.Estimate(context.Background(), firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate model: %v", err)
+	}
+
+	// The database stores 40,000,000 documents, 40GB.
+	tt := []struct {
+		name     string
+		estimate scenario.Estimate
+		category scenario.Category
+		want     firestore.Money
+	}{
+		// (40,000,000 - 50,000) / 100,000 * 0.06 * 30 daily exports
+		{"total", e.Total, scenario.CategoryRead, 71910 * firestore.Cent},
+		// (40,000,000 - 20,000) / 100,000 * 0.18 = 71.964 rounded to the cent
+		{"total", e.Total, scenario.CategoryWrite, 7196 * firestore.Cent},
+		// 40GB * 7 kept exports * 0.026
+		{"total", e.Total, scenario.CategoryExport, 728 * firestore.Cent},
+		{"users", e.Collections["users"], scenario.CategoryExport, 182 * firestore.Cent},
+		{"logs", e.Collections["logs"], scenario.CategoryExport, 546 * firestore.Cent},
+	}
+
+	for _, tc := range tt {
+		if got := tc.estimate[tc.category]; tc.want != got {
+			t.Errorf("want %s %v cost %v, got %v", tc.name, tc.category, tc.want, got)
+		}
+	}
+}
+
+func TestModel_Estimate_RecursiveDeletes(t *testing.T) {
+	m, err := scenario.ReadModel(strings.NewReader(`{
+		"collections": [
+			{"path": "profiles/{profileId}", "document_size": 500, "documents": 1000000, "recursive_deletes": 2000},
+			{"path": "profiles/{profileId}/logs/{logId}", "document_size": 500, "fan_out": 20},
+			{"path": "profiles/{profileId}/logs/{logId}/entries/{entryId}", "document_size": 500, "fan_out": 5},
+			{"path": "profiles/{profileId}/settings/{settingId}", "document_size": 500, "documents": 500000}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("unable to read model: %v", err)
+	}
+
+	rd, err := m.RecursiveDelete("profiles")
+	if err != nil {
+		t.Fatalf("unable to model recursive delete: %v", err)
+	}
+
+	// 2,000 profiles, 40,000 logs, 200,000 entries and 1,000 settings a day.
+	if want, got := int64(243000), rd.Deletes(big.NewInt(2000)).Int64(); want != got {
+		t.Errorf("want %v daily deletes, got %v", want, got)
+	}
+
+	e, err := m.Estimate(context.Background(), firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate model: %v", err)
+	}
+
+	tt := []struct {
+		name     string
+		estimate scenario.Estimate
+		category scenario.Category
+		want     firestore.Money
+	}{
+		// (243,000 - 20,000) / 100,000 * 0.02 * 30 = 1.338
+		{"total", e.Total, scenario.CategoryDelete, 134 * firestore.Cent},
+		// (241,000 - 50,000) / 100,000 * 0.06 * 30 = 3.438
+		{"total", e.Total, scenario.CategoryRead, 344 * firestore.Cent},
+		// Profiles have subcollection documents to read.
+		{"profiles", e.Collections["profiles"], scenario.CategoryRead, 0},
+	}
+
+	for _, tc := range tt {
+		if got := tc.estimate[tc.category]; tc.want != got {
+			t.Errorf("want %s %v cost %v, got %v", tc.name, tc.category, tc.want, got)
+		}
+	}
+
+	if _, err := m.RecursiveDelete("users"); err == nil {
+		t.Error("want error for an unknown collection, got nil")
+	}
+}
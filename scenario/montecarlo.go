@@ -0,0 +1,222 @@
+package scenario
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+// Distribution is a probability distribution of an input value.
+type Distribution interface {
+	// Sample draws a value using r.
+	Sample(r *rand.Rand) float64
+}
+
+// Uniform is a uniform distribution between Min and Max.
+type Uniform struct {
+	Min, Max float64
+}
+
+// Sample draws a value using r.
+func (d Uniform) Sample(r *rand.Rand) float64 {
+	return d.Min + r.Float64()*(d.Max-d.Min)
+}
+
+// Normal is a normal distribution.
+type Normal struct {
+	Mean, StdDev float64
+}
+
+// Sample draws a value using r.
+func (d Normal) Sample(r *rand.Rand) float64 {
+	return d.Mean + r.NormFloat64()*d.StdDev
+}
+
+// LogNormal is a distribution whose logarithm is normal with mean Mu and
+// standard deviation Sigma.
+type LogNormal struct {
+	Mu, Sigma float64
+}
+
+// Sample draws a value using r.
+func (d LogNormal) Sample(r *rand.Rand) float64 {
+	return math.Exp(d.Mu + r.NormFloat64()*d.Sigma)
+}
+
+// Triangular is a triangular distribution between Min and Max peaking at
+// Mode.
+type Triangular struct {
+	Min, Mode, Max float64
+}
+
+// Sample draws a value using r.
+func (d Triangular) Sample(r *rand.Rand) float64 {
+	u := r.Float64()
+	width := d.Max - d.Min
+	if width == 0 {
+		return d.Min
+	}
+
+	if u < (d.Mode-d.Min)/width {
+		return d.Min + math.Sqrt(u*width*(d.Mode-d.Min))
+	}
+
+	return d.Max - math.Sqrt((1-u)*width*(d.Max-d.Mode))
+}
+
+// ParseDistribution parses a distribution written as kind:p1,p2[,p3]:
+//
+//	uniform:min,max
+//	normal:mean,stddev
+//	lognormal:mu,sigma
+//	triangular:min,mode,max
+func ParseDistribution(s string) (Distribution, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("%q: want kind:parameters", s)
+	}
+
+	var params []float64
+	for _, p := range strings.Split(parts[1], ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", s, err)
+		}
+		params = append(params, v)
+	}
+
+	want := 2
+	if parts[0] == "triangular" {
+		want = 3
+	}
+	if len(params) != want {
+		return nil, fmt.Errorf("%q: want %d parameters", s, want)
+	}
+
+	switch parts[0] {
+	case "uniform":
+		if params[0] > params[1] {
+			return nil, fmt.Errorf("%q: min is greater than max", s)
+		}
+		return Uniform{Min: params[0], Max: params[1]}, nil
+	case "normal":
+		return Normal{Mean: params[0], StdDev: params[1]}, nil
+	case "lognormal":
+		return LogNormal{Mu: params[0], Sigma: params[1]}, nil
+	case "triangular":
+		if params[0] > params[1] || params[1] > params[2] {
+			return nil, fmt.Errorf("%q: want min <= mode <= max", s)
+		}
+		return Triangular{Min: params[0], Mode: params[1], Max: params[2]}, nil
+	}
+
+	return nil, fmt.Errorf("%q: unknown distribution %q", s, parts[0])
+}
+
+// Simulation holds the sorted outcomes of every trial of a Monte Carlo run.
+type Simulation struct {
+	// Trials is the number of trials run.
+	Trials int
+
+	// Costs holds the sorted monthly cost of each category.
+	Costs map[Category][]firestore.Money
+
+	// Totals holds the sorted total monthly costs.
+	Totals []firestore.Money
+}
+
+// Percentile returns the q-th percentile (0-100) of sorted costs using the
+// nearest-rank method.
+func Percentile(costs []firestore.Money, q float64) firestore.Money {
+	if len(costs) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(q / 100 * float64(len(costs))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(costs) {
+		rank = len(costs)
+	}
+
+	return costs[rank-1]
+}
+
+// Simulate runs trials estimates of base with the inputs in dists drawn from
+// their distribution. Samples below zero are clamped to zero. The same seed
+// always gives the same results.
+func Simulate(
+	ctx context.Context,
+	p firestore.PriceList,
+	base *Scenario,
+	dists map[string]Distribution,
+	trials int,
+	seed int64,
+) (*Simulation, error) {
+	if trials < 1 {
+		return nil, errors.New("at least one trial is required")
+	}
+
+	// Draw inputs in a fixed order so the seed reproduces the results.
+	var names []string
+	for name := range dists {
+		if _, err := LookupInput(name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	r := rand.New(rand.NewSource(seed))
+
+	sim := &Simulation{
+		Trials: trials,
+		Costs:  map[Category][]firestore.Money{},
+	}
+	for i := 0; i < trials; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		s := base
+		for _, name := range names {
+			in, _ := LookupInput(name)
+
+			v := math.Max(dists[name].Sample(r), 0)
+
+			var err error
+			if s, err = in.With(s, v); err != nil {
+				return nil, err
+			}
+		}
+
+		e, err := s.Estimate(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range Used(e) {
+			sim.Costs[c] = append(sim.Costs[c], e[c])
+		}
+		sim.Totals = append(sim.Totals, e.Total())
+	}
+
+	for _, costs := range sim.Costs {
+		sortMoney(costs)
+	}
+	sortMoney(sim.Totals)
+
+	return sim, nil
+}
+
+func sortMoney(m []firestore.Money) {
+	sort.Slice(m, func(i, j int) bool { return m[i] < m[j] })
+}
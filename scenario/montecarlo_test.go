@@ -0,0 +1,122 @@
+package scenario_test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+)
+
+func TestParseDistribution(t *testing.T) {
+	tt := []struct {
+		input string
+		want  scenario.Distribution
+		err   bool
+	}{
+		{"uniform:1,4", scenario.Uniform{Min: 1, Max: 4}, false},
+		{"normal:100,10", scenario.Normal{Mean: 100, StdDev: 10}, false},
+		{"lognormal:2.3,0.4", scenario.LogNormal{Mu: 2.3, Sigma: 0.4}, false},
+		{"triangular:1,2,5", scenario.Triangular{Min: 1, Mode: 2, Max: 5}, false},
+		{"triangular:1,6,5", nil, true},
+		{"uniform:1", nil, true},
+		{"poisson:1,2", nil, true},
+		{"uniform", nil, true},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := scenario.ParseDistribution(tc.input)
+			if tc.err != (err != nil) {
+				t.Fatalf("want error %v, got %v", tc.err, err)
+			}
+
+			if !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want ParseDistribution() = %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestTriangular_Sample(t *testing.T) {
+	d := scenario.Triangular{Min: 1, Mode: 2, Max: 5}
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+		if v := d.Sample(r); v < d.Min || v > d.Max {
+			t.Fatalf("want sample within [%v, %v], got %v", d.Min, d.Max, v)
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	costs := []firestore.Money{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	tt := []struct {
+		q    float64
+		want firestore.Money
+	}{
+		{10, 1},
+		{50, 5},
+		{90, 9},
+		{99, 10},
+	}
+
+	for _, tc := range tt {
+		if got := scenario.Percentile(costs, tc.q); tc.want != got {
+			t.Errorf("want P%v = %v, got %v", tc.q, tc.want, got)
+		}
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	dists := map[string]scenario.Distribution{
+		scenario.InputPopulation: scenario.Normal{Mean: 1000000, StdDev: 200000},
+		scenario.InputReads:      scenario.Uniform{Min: 1, Max: 3},
+	}
+
+	run := func() *scenario.Simulation {
+		sim, err := scenario.Simulate(
+			context.Background(),
+			firestore.DefaultPrices,
+			scenario.Default(),
+			dists,
+			200,
+			42,
+		)
+		if err != nil {
+			t.Fatalf("unable to simulate costs: %v", err)
+		}
+
+		return sim
+	}
+
+	a, b := run(), run()
+	if !reflect.DeepEqual(a.Totals, b.Totals) {
+		t.Error("want the same seed to give the same results")
+	}
+
+	if len(a.Totals) != 200 || len(a.Costs[scenario.CategoryRead]) != 200 {
+		t.Errorf("want 200 outcomes, got %v", len(a.Totals))
+	}
+
+	p10, p90 := scenario.Percentile(a.Totals, 10), scenario.Percentile(a.Totals, 90)
+	if p10 >= p90 {
+		t.Errorf("want P10 %v below P90 %v", p10, p90)
+	}
+}
+
+func TestSimulate_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := scenario.Simulate(ctx, firestore.DefaultPrices, scenario.Default(), nil, 10, 1)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("want Simulate() error %v, got %v", context.Canceled, err)
+	}
+}
@@ -0,0 +1,166 @@
+package scenario
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+// Path is a document path template such as profiles/{profileId}/logs/{logId}.
+// Document IDs are literal IDs or placeholders, {name} for IDs of the default
+// strategy and {name:strategy} for IDs of a strategy such as uuid or a custom
+// length, e.g. {name:12}.
+type Path struct {
+	segments []segment
+}
+
+// segment is a collection ID or a document ID of a path.
+type segment struct {
+	// name is the literal ID or the name of the placeholder.
+	name string
+
+	// placeholder tells whether the segment is a placeholder.
+	placeholder bool
+
+	// id is the ID strategy of a placeholder, empty for the default one.
+	id firestore.IDStrategy
+}
+
+// ParsePath parses a document path template.
+func ParsePath(s string) (*Path, error) {
+	parts := strings.Split(s, "/")
+	if len(parts)%2 != 0 {
+		return nil, fmt.Errorf("%q is not a document path", s)
+	}
+
+	p := &Path{}
+	for i, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("%q has an empty segment", s)
+		}
+
+		if !strings.HasPrefix(part, "{") {
+			if strings.ContainsAny(part, "{}") {
+				return nil, fmt.Errorf("%q has an invalid segment %q", s, part)
+			}
+			p.segments = append(p.segments, segment{name: part})
+			continue
+		}
+
+		if i%2 == 0 {
+			return nil, fmt.Errorf("%q has a placeholder collection ID %q", s, part)
+		}
+		if !strings.HasSuffix(part, "}") {
+			return nil, fmt.Errorf("%q has an invalid placeholder %q", s, part)
+		}
+
+		seg, err := placeholder(part[1 : len(part)-1])
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", s, err)
+		}
+		p.segments = append(p.segments, seg)
+	}
+
+	return p, nil
+}
+
+// Parse a placeholder name with an optional ID strategy.
+func placeholder(s string) (segment, error) {
+	seg := segment{name: s, placeholder: true}
+
+	if i := strings.Index(s, ":"); i >= 0 {
+		id, err := firestore.ParseIDStrategy(s[i+1:])
+		if err != nil {
+			return segment{}, fmt.Errorf("placeholder %q: %w", s, err)
+		}
+		seg.name, seg.id = s[:i], id
+	}
+
+	if seg.name == "" {
+		return segment{}, fmt.Errorf("placeholder %q has no name", s)
+	}
+
+	return seg, nil
+}
+
+// String returns the template of p.
+func (p *Path) String() string {
+	parts := make([]string, len(p.segments))
+	for i, seg := range p.segments {
+		parts[i] = seg.String()
+	}
+
+	return strings.Join(parts, "/")
+}
+
+func (seg segment) String() string {
+	switch {
+	case !seg.placeholder:
+		return seg.name
+	case seg.id == "":
+		return "{" + seg.name + "}"
+	}
+
+	return fmt.Sprintf("{%s:%s}", seg.name, seg.id)
+}
+
+// Collection returns the template of the collection of p, e.g.
+// profiles/{profileId}/logs.
+func (p *Path) Collection() string {
+	return (&Path{segments: p.segments[:len(p.segments)-1]}).String()
+}
+
+// Parent returns the template of the parent document of p, or nil for root
+// collections.
+func (p *Path) Parent() *Path {
+	if len(p.segments) <= 2 {
+		return nil
+	}
+
+	return &Path{segments: p.segments[:len(p.segments)-2]}
+}
+
+// Document returns a sample document ID and collection path of p with every
+// placeholder replaced by an ID of its strategy, or of def when it has none.
+func (p *Path) Document(def firestore.IDStrategy) (id, collection string) {
+	if def == "" {
+		def = firestore.IDAuto
+	}
+
+	parts := make([]string, len(p.segments))
+	for i, seg := range p.segments {
+		switch {
+		case !seg.placeholder:
+			parts[i] = seg.name
+		case seg.id != "":
+			parts[i] = seg.id.SampleID()
+		default:
+			parts[i] = def.SampleID()
+		}
+	}
+
+	n := len(parts) - 1
+
+	return parts[n], strings.Join(parts[:n], "/")
+}
+
+// Match reports whether p and o are the same path with any placeholder names
+// and ID strategies.
+func (p *Path) Match(o *Path) bool {
+	if len(p.segments) != len(o.segments) {
+		return false
+	}
+
+	for i, seg := range p.segments {
+		other := o.segments[i]
+		if seg.placeholder != other.placeholder {
+			return false
+		}
+		if !seg.placeholder && seg.name != other.name {
+			return false
+		}
+	}
+
+	return true
+}
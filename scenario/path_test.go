@@ -0,0 +1,105 @@
+package scenario_test
+
+import (
+	"testing"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+)
+
+func TestParsePath(t *testing.T) {
+	tt := []struct {
+		path       string
+		def        firestore.IDStrategy
+		id         string
+		collection string
+		parent     string
+	}{
+		{"users/{userId}", "", "xxxxxxxxxxxxxxxxxxxx", "users", ""},
+		{"users/{userId}", firestore.IDNumeric, "__id1234567890__", "users", ""},
+		{"users/{userId:uuid}", firestore.IDNumeric, "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx", "users", ""},
+		{"users/jeff", firestore.IDULID, "jeff", "users", ""},
+		{
+			"users/{userId:numeric}/logs/{logId}",
+			"",
+			"xxxxxxxxxxxxxxxxxxxx",
+			"users/__id1234567890__/logs",
+			"users/{userId:numeric}",
+		},
+		{
+			"profiles/{profileId:4}/logs/{logId:2}",
+			firestore.IDUUID,
+			"xx",
+			"profiles/xxxx/logs",
+			"profiles/{profileId:4}",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.path, func(t *testing.T) {
+			p, err := scenario.ParsePath(tc.path)
+			if err != nil {
+				t.Fatalf("unable to parse path: %v", err)
+			}
+
+			if got := p.String(); got != tc.path {
+				t.Errorf("want template %v, got %v", tc.path, got)
+			}
+
+			id, collection := p.Document(tc.def)
+			if id != tc.id || collection != tc.collection {
+				t.Errorf("want %v/%v, got %v/%v", tc.collection, tc.id, collection, id)
+			}
+
+			parent := ""
+			if p.Parent() != nil {
+				parent = p.Parent().String()
+			}
+			if parent != tc.parent {
+				t.Errorf("want parent %q, got %q", tc.parent, parent)
+			}
+		})
+	}
+}
+
+func TestParsePath_Invalid(t *testing.T) {
+	for _, path := range []string{
+		"users",
+		"users/{userId}/logs",
+		"{collection}/{id}",
+		"users//logs/{id}",
+		"users/{userId:0}",
+		"users/{userId:guid}",
+		"users/{:20}",
+		"users/{userId",
+		"us{ers/{userId}",
+	} {
+		if _, err := scenario.ParsePath(path); err == nil {
+			t.Errorf("%s: want error, got nil", path)
+		}
+	}
+}
+
+func TestPath_Match(t *testing.T) {
+	tt := []struct {
+		a, b string
+		want bool
+	}{
+		{"profiles/{profileId}", "profiles/{id}", true},
+		{"profiles/{profileId}", "profiles/{profileId:36}", true},
+		{"profiles/{profileId}", "users/{profileId}", false},
+		{"profiles/jeff", "profiles/{profileId}", false},
+		{"profiles/{id}", "profiles/{id}/logs/{logId}", false},
+	}
+
+	for _, tc := range tt {
+		a, _ := scenario.ParsePath(tc.a)
+		b, _ := scenario.ParsePath(tc.b)
+
+		if got := a.Match(b); got != tc.want {
+			t.Errorf("%s, %s: want %v, got %v", tc.a, tc.b, tc.want, got)
+		}
+	}
+}
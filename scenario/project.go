@@ -0,0 +1,288 @@
+package scenario
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+// DefaultDatabase is the ID of the default database of a project.
+const DefaultDatabase = "(default)"
+
+// Edition is the Firestore edition of a database.
+type Edition string
+
+const (
+	// EditionStandard is the Standard edition.
+	EditionStandard Edition = "standard"
+
+	// EditionEnterprise is the Enterprise edition.
+	EditionEnterprise Edition = "enterprise"
+)
+
+// Project is a Google Cloud project of many Firestore databases.
+type Project struct {
+	// Name identifies the project in reports.
+	Name string `json:"name"`
+
+	// Locations overrides the unit prices of the project for the databases
+	// in a location, by location name.
+	Locations map[string]*firestore.PriceOverrides `json:"locations,omitempty"`
+
+	// Databases are the databases of the project.
+	Databases []*Database `json:"databases"`
+}
+
+// Database is a named database with its own workload.
+type Database struct {
+	// Name is the database ID, DefaultDatabase for the default database.
+	Name string `json:"name"`
+
+	// Location is the Firestore location of the database, the location of
+	// the scenario when empty.
+	Location string `json:"location,omitempty"`
+
+	// Edition is the edition of the database, EditionStandard when empty.
+	Edition Edition `json:"edition,omitempty"`
+
+	// FreeTier designates the database that uses the free quota. Only one
+	// database of a project has it, the default database when none is
+	// designated.
+	FreeTier bool `json:"free_tier,omitempty"`
+
+	// Prices overrides the unit prices of the project and of its location
+	// for the database. Enterprise databases require the prices of every
+	// SKU they use from either one since there is no enterprise price list.
+	Prices *firestore.PriceOverrides `json:"prices,omitempty"`
+
+	// Scenario is the workload and document model of the database.
+	Scenario *Scenario `json:"-"`
+}
+
+// ReadProject decodes and validates a JSON project. The scenario of every
+// database is decoded with the rules of Read.
+func ReadProject(r io.Reader) (*Project, error) {
+	var file struct {
+		Name      string                               `json:"name"`
+		Locations map[string]*firestore.PriceOverrides `json:"locations"`
+		Databases []*struct {
+			Database
+			Scenario json.RawMessage `json:"scenario"`
+		} `json:"databases"`
+	}
+
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("unable to decode project: %w", err)
+	}
+
+	p := &Project{Name: file.Name, Locations: file.Locations}
+	for i, d := range file.Databases {
+		if d == nil {
+			return nil, fmt.Errorf("invalid project: database %d is empty", i+1)
+		}
+
+		db := d.Database
+		db.Scenario = Default()
+
+		if len(d.Scenario) > 0 {
+			s, err := Read(bytes.NewReader(d.Scenario))
+			if err != nil {
+				return nil, fmt.Errorf("database %q: %w", db.Name, err)
+			}
+			db.Scenario = s
+		}
+
+		p.Databases = append(p.Databases, &db)
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid project: %w", err)
+	}
+
+	return p, nil
+}
+
+// LoadProject reads a JSON project file.
+func LoadProject(path string) (*Project, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open project: %w", err)
+	}
+	defer f.Close()
+
+	p, err := ReadProject(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return p, nil
+}
+
+// Validate reports the first invalid input of p.
+func (p *Project) Validate() error {
+	if len(p.Databases) == 0 {
+		return fmt.Errorf("databases are required")
+	}
+
+	for name, o := range p.Locations {
+		if err := o.Validate(); err != nil {
+			return fmt.Errorf("location %q: %w", name, err)
+		}
+	}
+
+	names := map[string]bool{}
+	free := 0
+	for i, db := range p.Databases {
+		if db == nil {
+			return fmt.Errorf("database %d is empty", i+1)
+		}
+		if err := db.validate(); err != nil {
+			return fmt.Errorf("database %d: %w", i+1, err)
+		}
+		if names[db.Name] {
+			return fmt.Errorf("database %q is defined twice", db.Name)
+		}
+		names[db.Name] = true
+
+		if db.Edition == EditionEnterprise {
+			if missing := db.unpriced(p.Locations); len(missing) > 0 {
+				return fmt.Errorf(
+					"database %q: enterprise edition has no list prices, prices of %s are required",
+					db.Name,
+					strings.Join(missing, ", "),
+				)
+			}
+		}
+
+		if db.FreeTier {
+			free++
+		}
+	}
+
+	if free > 1 {
+		return fmt.Errorf("%d databases have the free tier, only one can", free)
+	}
+
+	return nil
+}
+
+// Report the first invalid input of db, and set its defaults.
+func (db *Database) validate() error {
+	if db.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	switch Edition(strings.ToLower(string(db.Edition))) {
+	case "", EditionStandard:
+		db.Edition = EditionStandard
+	case EditionEnterprise:
+		db.Edition = EditionEnterprise
+	default:
+		return fmt.Errorf("unknown edition %q", db.Edition)
+	}
+
+	if db.Scenario == nil {
+		return fmt.Errorf("scenario is required")
+	}
+
+	if db.Location == "" {
+		db.Location = db.Scenario.Location
+	}
+
+	if err := db.Prices.Validate(); err != nil {
+		return err
+	}
+
+	return db.Scenario.Validate()
+}
+
+// Return the SKUs the scenario of db uses without a price override of db or
+// of its location.
+func (db *Database) unpriced(locations map[string]*firestore.PriceOverrides) []string {
+	p := db.overrides(locations)
+
+	skus := []struct {
+		name  string
+		price *firestore.Money
+		used  bool
+	}{
+		{"write", p.Write, true},
+		{"read", p.Read, true},
+		{"delete", p.Delete, true},
+		{"storage", p.Storage, true},
+		{"ingress", p.Ingress, true},
+		{"backup", p.Backup, db.Scenario.Backup != nil},
+		{"restore", p.Restore, db.Scenario.Backup != nil && db.Scenario.Backup.Restores > 0},
+		{"pitr", p.PITR, db.Scenario.Backup != nil && db.Scenario.Backup.PITR},
+		{"export_storage", p.ExportStorage, db.Scenario.Export != nil},
+	}
+
+	var missing []string
+	for _, sku := range skus {
+		if sku.used && sku.price == nil {
+			missing = append(missing, sku.name)
+		}
+	}
+
+	return missing
+}
+
+// Free returns the database that uses the free quota, nil when there is none.
+func (p *Project) Free() *Database {
+	for _, db := range p.Databases {
+		if db.FreeTier {
+			return db
+		}
+	}
+
+	for _, db := range p.Databases {
+		if db.Name == DefaultDatabase {
+			return db
+		}
+	}
+
+	return nil
+}
+
+// ProjectEstimate is the monthly cost of a project.
+type ProjectEstimate struct {
+	// Databases is the cost of every database by name.
+	Databases map[string]Estimate
+
+	// Total is the cost of the whole project.
+	Total Estimate
+}
+
+// Estimate calculates the monthly cost of every database of p using the unit
+// prices in prices and the price overrides of its location and of the
+// database. Only the free database uses the free quota.
+func (p *Project) Estimate(ctx context.Context, prices firestore.PriceList) (*ProjectEstimate, error) {
+	free := p.Free()
+
+	e := &ProjectEstimate{Databases: map[string]Estimate{}, Total: Estimate{}}
+	for _, db := range p.Databases {
+		de, err := db.Scenario.estimate(ctx, db.overrides(p.Locations).Apply(prices), db == free)
+		if err != nil {
+			return nil, fmt.Errorf("unable to estimate %s: %w", db.Name, err)
+		}
+
+		e.Databases[db.Name] = de
+		for c, cost := range de {
+			e.Total[c] += cost
+		}
+	}
+
+	return e, nil
+}
+
+// Return the price overrides of the location of db, replaced by the ones of
+// db.
+func (db *Database) overrides(locations map[string]*firestore.PriceOverrides) *firestore.PriceOverrides {
+	return locations[db.Location].Merge(db.Prices)
+}
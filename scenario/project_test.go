@@ -0,0 +1,200 @@
+package scenario_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+)
+
+const project = `{
+	"name": "acme",
+	"databases": [
+		{
+			"name": "(default)",
+			"location": "nam5",
+			"scenario": {"population": 10000, "count": 2, "writes": 1, "reads": 2, "deletes": 0.5}
+		},
+		{
+			"name": "analytics",
+			"location": "europe-west3",
+			"edition": "Enterprise",
+			"prices": {
+				"write": "0.18", "read": "0.1", "delete": "0.02",
+				"storage": "0.18", "ingress": "0.12"
+			},
+			"scenario": {"population": 10000, "count": 2, "writes": 1, "reads": 2, "deletes": 0.5}
+		}
+	]
+}`
+
+func TestProject_Estimate(t *testing.T) {
+	p, err := scenario.ReadProject(strings.NewReader(project))
+	if err != nil {
+		t.Fatalf("unable to read project: %v", err)
+	}
+
+	if free := p.Free(); free == nil || free.Name != scenario.DefaultDatabase {
+		t.Errorf("want the default database to have the free tier, got %v", free)
+	}
+
+	if want, got := scenario.EditionEnterprise, p.Databases[1].Edition; want != got {
+		t.Errorf("want edition %v, got %v", want, got)
+	}
+
+	e, err := p.Estimate(context.Background(), firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate project: %v", err)
+	}
+
+	// Both databases write 20,000, read 40,000 and delete 10,000 documents a
+	// day, within the free quota.
+	tt := []struct {
+		name     string
+		estimate scenario.Estimate
+		category scenario.Category
+		want     firestore.Money
+	}{
+		{"(default)", e.Databases["(default)"], scenario.CategoryWrite, 0},
+		{"(default)", e.Databases["(default)"], scenario.CategoryRead, 0},
+		// 20,000 / 100,000 * 0.18 * 30
+		{"analytics", e.Databases["analytics"], scenario.CategoryWrite, 108 * firestore.Cent},
+		// 40,000 / 100,000 * 0.1 * 30
+		{"analytics", e.Databases["analytics"], scenario.CategoryRead, 120 * firestore.Cent},
+		{"project", e.Total, scenario.CategoryRead, 120 * firestore.Cent},
+	}
+
+	for _, tc := range tt {
+		if got := tc.estimate[tc.category]; tc.want != got {
+			t.Errorf("want %s %v cost %v, got %v", tc.name, tc.category, tc.want, got)
+		}
+	}
+
+	var total firestore.Money
+	for _, de := range e.Databases {
+		total += de.Total()
+	}
+	if want := e.Total.Total(); want != total {
+		t.Errorf("want database totals to add up to %v, got %v", want, total)
+	}
+}
+
+func TestProject_Estimate_Overrides(t *testing.T) {
+	p, err := scenario.ReadProject(strings.NewReader(`{
+		"locations": {
+			"eur3": {"write": "0.2", "read": "0.1", "delete": "0.02", "storage": "0.2"}
+		},
+		"databases": [
+			{
+				"name": "orders",
+				"location": "eur3",
+				"edition": "enterprise",
+				"prices": {"read": "0", "ingress": "0.12"},
+				"scenario": {"population": 10000, "count": 2, "writes": 1, "reads": 2, "deletes": 0.5}
+			},
+			{
+				"name": "logs",
+				"location": "nam5",
+				"prices": {"write": "0"},
+				"scenario": {"population": 10000, "count": 2, "writes": 1, "reads": 2, "deletes": 0.5}
+			}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("unable to read project: %v", err)
+	}
+
+	e, err := p.Estimate(context.Background(), firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate project: %v", err)
+	}
+
+	// Neither database has the free tier, both write 20,000, read 40,000
+	// and delete 10,000 documents a day.
+	tt := []struct {
+		name     string
+		category scenario.Category
+		want     firestore.Money
+	}{
+		// 20,000 / 100,000 * 0.2 * 30, the location price.
+		{"orders", scenario.CategoryWrite, 120 * firestore.Cent},
+		// The free price of the database replaces the one of its location.
+		{"orders", scenario.CategoryRead, 0},
+		// 10,000 / 100,000 * 0.02 * 30
+		{"orders", scenario.CategoryDelete, 6 * firestore.Cent},
+		{"logs", scenario.CategoryWrite, 0},
+		// 40,000 / 100,000 * 0.06 * 30, the list price.
+		{"logs", scenario.CategoryRead, 72 * firestore.Cent},
+	}
+
+	for _, tc := range tt {
+		if got := e.Databases[tc.name][tc.category]; tc.want != got {
+			t.Errorf("want %s %v cost %v, got %v", tc.name, tc.category, tc.want, got)
+		}
+	}
+}
+
+func TestProject_Free(t *testing.T) {
+	p, err := scenario.ReadProject(strings.NewReader(`{"databases": [
+		{"name": "(default)"},
+		{"name": "orders", "free_tier": true}
+	]}`))
+	if err != nil {
+		t.Fatalf("unable to read project: %v", err)
+	}
+
+	if free := p.Free(); free == nil || free.Name != "orders" {
+		t.Errorf("want the designated database to have the free tier, got %v", free)
+	}
+
+	p, err = scenario.ReadProject(strings.NewReader(`{"databases": [{"name": "orders"}]}`))
+	if err != nil {
+		t.Fatalf("unable to read project: %v", err)
+	}
+
+	if free := p.Free(); free != nil {
+		t.Errorf("want no free tier without a default database, got %v", free)
+	}
+}
+
+func TestReadProject_Invalid(t *testing.T) {
+	tt := []struct {
+		name  string
+		input string
+	}{
+		{"no databases", `{"name": "acme"}`},
+		{"missing name", `{"databases": [{"location": "nam5"}]}`},
+		{"duplicate", `{"databases": [{"name": "a"}, {"name": "a"}]}`},
+		{"edition", `{"databases": [{"name": "a", "edition": "premium"}]}`},
+		{"two free tiers", `{"databases": [
+			{"name": "a", "free_tier": true},
+			{"name": "b", "free_tier": true}
+		]}`},
+		{"enterprise list prices", `{"databases": [
+			{"name": "a", "edition": "enterprise", "prices": {"read": "0.1"}}
+		]}`},
+		{"enterprise free prices unset", `{"databases": [
+			{"name": "a", "edition": "enterprise", "prices": {
+				"write": "0", "read": "0", "delete": "0", "storage": "0"
+			}}
+		]}`},
+		{"negative price", `{"databases": [{"name": "a", "prices": {"read": "-1"}}]}`},
+		{"negative location price", `{
+			"locations": {"nam5": {"read": "-1"}},
+			"databases": [{"name": "a"}]
+		}`},
+		{"scenario", `{"databases": [{"name": "a", "scenario": {"population": -1}}]}`},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := scenario.ReadProject(strings.NewReader(tc.input)); err == nil {
+				t.Error("want error, got nil")
+			}
+		})
+	}
+}
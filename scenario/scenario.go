@@ -0,0 +1,379 @@
+// Package scenario defines reusable estimate inputs: a workload and the
+// document model it stores.
+package scenario
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+// Workload describes the traffic of a scenario.
+type Workload struct {
+	// Population is the total number of active users.
+	Population int64 `json:"population"`
+
+	// Count is the number of daily transactions per user.
+	Count int64 `json:"count"`
+
+	// Writes is the number of document writes per transaction.
+	Writes float64 `json:"writes"`
+
+	// Reads is the number of document reads per transaction.
+	Reads float64 `json:"reads"`
+
+	// Deletes is the number of document deletes per transaction.
+	Deletes float64 `json:"deletes"`
+}
+
+// Scenario is a named workload and document model.
+type Scenario struct {
+	// Name identifies the scenario in reports.
+	Name string `json:"name"`
+
+	Workload
+
+	// Document is the stored document model.
+	Document *firestore.Document `json:"document"`
+
+	// DocumentSize overrides the stored and transferred size of Document in
+	// bytes when set.
+	DocumentSize int64 `json:"document_size,omitempty"`
+
+	// IDStrategy generates the ID and the parent document IDs of Document,
+	// its literal IDs are kept when it is empty.
+	IDStrategy firestore.IDStrategy `json:"id_strategy,omitempty"`
+
+	// TTLDays is the number of days documents live before a TTL policy
+	// deletes them, zero when they are kept.
+	TTLDays int64 `json:"ttl_days,omitempty"`
+
+	// QueriedFields lists the fields used in queries. Fields of composite
+	// indexes are used when it is empty.
+	QueriedFields []string `json:"queried_fields,omitempty"`
+
+	// Location is the Firestore location of the database.
+	Location string `json:"location,omitempty"`
+
+	// Backup is the backup and recovery setup of the database, nil when
+	// there is none.
+	Backup *Backup `json:"backup,omitempty"`
+
+	// Export is the managed export and import schedule of the database, nil
+	// when there is none.
+	Export *Export `json:"export,omitempty"`
+
+	// Profile is the daily traffic of calendar month estimates, the same
+	// every day when nil.
+	Profile *Profile `json:"profile,omitempty"`
+}
+
+// Backup is the backup and recovery setup of a database.
+type Backup struct {
+	// RetentionDays is the number of days every scheduled backup is kept.
+	RetentionDays int64 `json:"retention_days,omitempty"`
+
+	// IntervalDays is the number of days between scheduled backups, 1 for
+	// daily and 7 for weekly backups.
+	IntervalDays int64 `json:"interval_days,omitempty"`
+
+	// Restores is the number of full restores from a backup every month.
+	Restores int64 `json:"restores,omitempty"`
+
+	// PITR tells whether point-in-time recovery is enabled.
+	PITR bool `json:"pitr,omitempty"`
+}
+
+// Report the first invalid input of b.
+func (b *Backup) validate() error {
+	switch {
+	case b.RetentionDays < 0:
+		return fmt.Errorf("backup retention_days %d is negative", b.RetentionDays)
+	case b.IntervalDays < 0:
+		return fmt.Errorf("backup interval_days %d is negative", b.IntervalDays)
+	case b.Restores < 0:
+		return fmt.Errorf("backup restores %d is negative", b.Restores)
+	}
+
+	return nil
+}
+
+// Export is the managed export and import schedule of a database.
+type Export struct {
+	// IntervalDays is the number of days between exports, 1 for daily and 7
+	// for weekly exports.
+	IntervalDays int64 `json:"interval_days,omitempty"`
+
+	// RetentionDays is the number of days every export is kept in Cloud
+	// Storage.
+	RetentionDays int64 `json:"retention_days,omitempty"`
+
+	// Imports is the number of full imports every month.
+	Imports int64 `json:"imports,omitempty"`
+}
+
+// Report the first invalid input of x.
+func (x *Export) validate() error {
+	switch {
+	case x.IntervalDays < 0:
+		return fmt.Errorf("export interval_days %d is negative", x.IntervalDays)
+	case x.RetentionDays < 0:
+		return fmt.Errorf("export retention_days %d is negative", x.RetentionDays)
+	case x.Imports < 0:
+		return fmt.Errorf("export imports %d is negative", x.Imports)
+	}
+
+	return nil
+}
+
+// Return the daily writes that keep point-in-time recovery versions, none
+// when it is disabled.
+func (b *Backup) pitrWrites(writes *big.Int) *big.Int {
+	if !b.PITR {
+		return new(big.Int)
+	}
+
+	return writes
+}
+
+// Default returns the scenario used when no inputs are given.
+func Default() *Scenario {
+	return &Scenario{
+		Name: "default",
+		Workload: Workload{
+			Population: 1000000,
+			Count:      10,
+			Writes:     1,
+			Reads:      1,
+			Deletes:    1,
+		},
+		Document: DefaultDocument(),
+	}
+}
+
+// Fixed IDs and creation time of the sample document, so estimates of it are
+// the same on every run. The IDs have the length of UUID strings.
+const (
+	sampleRecordID   = "6f1c8a2e-4b7d-4e19-9a3f-0c5d7e8b2a41"
+	sampleProfileID  = "2d9e4f71-8c3a-4b6e-b1d5-7a0f3c9e6b28"
+	sampleMerchantID = "a47b3c9d-1e2f-4a8b-9c6d-5e0f1a2b3c4d"
+	sampleQRID       = "c3e5a7b9-0d2f-4e6a-8b1c-3d5e7f9a1b2c"
+	sampleProfileQR  = "e8f0a2b4-6c8d-4f1a-a3b5-c7d9e1f3a5b7"
+)
+
+// sampleTime is the fixed creation time of the sample document, with
+// nanoseconds like a time.Now() value.
+var sampleTime = time.Date(2021, time.June, 1, 8, 30, 15, 123456789, time.UTC)
+
+// DefaultDocument returns the sample QR record document.
+func DefaultDocument() *firestore.Document {
+	return &firestore.Document{
+		ID:         sampleRecordID,
+		Collection: fmt.Sprintf("prod-qr/%s/qr-records", sampleProfileID),
+		Data: map[string]interface{}{
+			"merchant_id":    sampleMerchantID,
+			"merchant_qr_id": sampleQRID,
+			"profile_qr_id":  sampleProfileQR,
+			"date_created":   sampleTime,
+			"type":           1,
+			"is_auto_scanout": map[string]interface{}{
+				"Bool":  false,
+				"Valid": false,
+			},
+		},
+		SingleFieldIndexes: []map[string]interface{}{
+			{
+				"date_created": sampleTime,
+			},
+		},
+		CompositeIndexes: []map[string]interface{}{
+			{
+				"merchant_id":  sampleMerchantID,
+				"date_created": sampleTime,
+			},
+			{
+				"merchant_id":  sampleMerchantID,
+				"type":         1,
+				"date_created": sampleTime,
+			},
+			{
+				"type":         1,
+				"date_created": sampleTime,
+			},
+		},
+	}
+}
+
+// Read decodes and validates a JSON scenario. Inputs missing from r keep the
+// values of Default, and string values in RFC 3339 format are treated as
+// timestamps.
+func Read(r io.Reader) (*Scenario, error) {
+	s := Default()
+	s.Document = nil
+
+	if err := json.NewDecoder(r).Decode(s); err != nil {
+		return nil, fmt.Errorf("unable to decode scenario: %w", err)
+	}
+
+	if s.Document == nil {
+		s.Document = DefaultDocument()
+	}
+
+	normalize(s.Document)
+
+	if s.IDStrategy != "" {
+		id, err := firestore.ParseIDStrategy(string(s.IDStrategy))
+		if err != nil {
+			return nil, fmt.Errorf("invalid scenario: %w", err)
+		}
+		s.IDStrategy = id
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario: %w", err)
+	}
+
+	return s, nil
+}
+
+// ReadDocument decodes and validates a JSON document model with the rules of
+// Read.
+func ReadDocument(r io.Reader) (*firestore.Document, error) {
+	doc := &firestore.Document{}
+
+	if err := json.NewDecoder(r).Decode(doc); err != nil {
+		return nil, fmt.Errorf("unable to decode document: %w", err)
+	}
+
+	normalize(doc)
+
+	s := Default()
+	s.Document = doc
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+
+	return doc, nil
+}
+
+// Validate reports the first invalid input of s.
+func (s *Scenario) Validate() error {
+	switch {
+	case s.Population < 0:
+		return fmt.Errorf("population %d is negative", s.Population)
+	case s.Count < 0:
+		return fmt.Errorf("count %d is negative", s.Count)
+	case s.Count != 0 && s.Population > math.MaxInt64/s.Count:
+		return fmt.Errorf("population %d times count %d is too large", s.Population, s.Count)
+	case s.Writes < 0 || s.Reads < 0 || s.Deletes < 0:
+		return fmt.Errorf("operations per transaction must not be negative")
+	case s.DocumentSize < 0:
+		return fmt.Errorf("document_size %d is negative", s.DocumentSize)
+	case s.TTLDays < 0:
+		return fmt.Errorf("ttl_days %d is negative", s.TTLDays)
+	case s.Document == nil:
+		return fmt.Errorf("document is required")
+	case s.Document.Collection == "":
+		return fmt.Errorf("document collection is required")
+	case strings.Count(s.Document.Collection, "/")%2 != 0:
+		return fmt.Errorf("%q is not a collection path", s.Document.Collection)
+	}
+
+	if s.IDStrategy != "" {
+		if _, err := firestore.ParseIDStrategy(string(s.IDStrategy)); err != nil {
+			return err
+		}
+	}
+
+	if s.Backup != nil {
+		if err := s.Backup.validate(); err != nil {
+			return err
+		}
+	}
+
+	if s.Export != nil {
+		if err := s.Export.validate(); err != nil {
+			return err
+		}
+	}
+
+	if s.Profile != nil {
+		return s.Profile.validate()
+	}
+
+	return nil
+}
+
+// document returns Document with the IDs of the ID strategy of s.
+func (s *Scenario) document() *firestore.Document {
+	if s.IDStrategy == "" {
+		return s.Document
+	}
+
+	return s.Document.WithIDs(s.IDStrategy)
+}
+
+// Load reads a JSON scenario file.
+func Load(path string) (*Scenario, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open scenario: %w", err)
+	}
+	defer f.Close()
+
+	s, err := Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// Save writes s to a JSON scenario file.
+func Save(path string, s *Scenario) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode scenario: %w", err)
+	}
+
+	if err := ioutil.WriteFile(path, append(b, '\n'), 0644); err != nil {
+		return fmt.Errorf("unable to save scenario: %w", err)
+	}
+
+	return nil
+}
+
+// Replace the RFC 3339 strings of doc with time values.
+func normalize(doc *firestore.Document) {
+	doc.Data = timestamps(doc.Data)
+	for i := range doc.SingleFieldIndexes {
+		doc.SingleFieldIndexes[i] = timestamps(doc.SingleFieldIndexes[i])
+	}
+	for i := range doc.CompositeIndexes {
+		doc.CompositeIndexes[i] = timestamps(doc.CompositeIndexes[i])
+	}
+}
+
+// Replace RFC 3339 strings in data with time values.
+func timestamps(data map[string]interface{}) map[string]interface{} {
+	for k, v := range data {
+		switch v := v.(type) {
+		case string:
+			if t, err := time.Parse(time.RFC3339, v); err == nil {
+				data[k] = t
+			}
+		case map[string]interface{}:
+			data[k] = timestamps(v)
+		}
+	}
+
+	return data
+}
@@ -0,0 +1,88 @@
+package scenario_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/royge/gostcalc/scenario"
+)
+
+func TestRead(t *testing.T) {
+	s, err := scenario.Read(strings.NewReader(`{
+		"name": "logs",
+		"population": 5000,
+		"document": {
+			"id": "my_task_id",
+			"collection": "users/jeff/tasks",
+			"data": {
+				"created": "2026-10-01T00:00:00Z",
+				"meta": {"updated": "2026-10-02T00:00:00Z"}
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("unable to read scenario: %v", err)
+	}
+
+	if s.Population != 5000 {
+		t.Errorf("want population 5000, got %v", s.Population)
+	}
+
+	if want := scenario.Default().Count; s.Count != want {
+		t.Errorf("want default count %v, got %v", want, s.Count)
+	}
+
+	if _, ok := s.Document.Data["created"].(time.Time); !ok {
+		t.Errorf("want created to be a timestamp, got %T", s.Document.Data["created"])
+	}
+
+	meta := s.Document.Data["meta"].(map[string]interface{})
+	if _, ok := meta["updated"].(time.Time); !ok {
+		t.Errorf("want meta.updated to be a timestamp, got %T", meta["updated"])
+	}
+}
+
+func TestRead_Invalid(t *testing.T) {
+	tt := []struct {
+		name  string
+		input string
+	}{
+		{"negative population", `{"population": -1}`},
+		{"negative reads", `{"reads": -0.5}`},
+		{"document path", `{"document": {"id": "a", "collection": "users/jeff"}}`},
+		{"missing collection", `{"document": {"id": "a"}}`},
+		{"unknown json", `{"population": "many"}`},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := scenario.Read(strings.NewReader(tc.input)); err == nil {
+				t.Errorf("want Read() error, got nil")
+			}
+		})
+	}
+}
+
+func TestSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scenario.json")
+
+	s := scenario.Default()
+	s.Location = "nam5"
+
+	if err := scenario.Save(path, s); err != nil {
+		t.Fatalf("unable to save scenario: %v", err)
+	}
+
+	got, err := scenario.Load(path)
+	if err != nil {
+		t.Fatalf("unable to load scenario: %v", err)
+	}
+
+	if got.Location != s.Location || got.Document.Size() != s.Document.Size() {
+		t.Errorf("want saved scenario %+v, got %+v", s, got)
+	}
+}
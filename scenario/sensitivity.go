@@ -0,0 +1,154 @@
+package scenario
+
+import (
+	"context"
+	"sort"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+// Range is the low, base and high value of an input.
+type Range struct {
+	Input string
+	Low   float64
+	Base  float64
+	High  float64
+}
+
+// Swing is the total monthly cost at the low and high value of an input
+// while every other input keeps its base value.
+type Swing struct {
+	Range
+
+	// LowTotal is the total cost at the low value.
+	LowTotal firestore.Money
+
+	// HighTotal is the total cost at the high value.
+	HighTotal firestore.Money
+}
+
+// Width returns the absolute difference between the high and low totals.
+func (s Swing) Width() firestore.Money {
+	if s.HighTotal < s.LowTotal {
+		return s.LowTotal - s.HighTotal
+	}
+
+	return s.HighTotal - s.LowTotal
+}
+
+// Tornado holds the swings of every input ranked by width.
+type Tornado struct {
+	// Base is the scenario with every input at its base value.
+	Base *Scenario
+
+	// BaseTotal is the total cost of Base.
+	BaseTotal firestore.Money
+
+	// Swings ranked from the widest.
+	Swings []Swing
+}
+
+// Sensitivity varies every input of ranges one at a time from the base
+// scenario, with all other inputs at their base values.
+func Sensitivity(ctx context.Context, p firestore.PriceList, base *Scenario, ranges []Range) (*Tornado, error) {
+	for _, r := range ranges {
+		in, err := LookupInput(r.Input)
+		if err != nil {
+			return nil, err
+		}
+
+		if base, err = in.With(base, r.Base); err != nil {
+			return nil, err
+		}
+	}
+
+	e, err := base.Estimate(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Tornado{Base: base, BaseTotal: e.Total()}
+	for _, r := range ranges {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		in, _ := LookupInput(r.Input)
+
+		low, err := total(ctx, p, in, base, r.Low)
+		if err != nil {
+			return nil, err
+		}
+
+		high, err := total(ctx, p, in, base, r.High)
+		if err != nil {
+			return nil, err
+		}
+
+		t.Swings = append(t.Swings, Swing{Range: r, LowTotal: low, HighTotal: high})
+	}
+
+	sort.SliceStable(t.Swings, func(i, j int) bool {
+		return t.Swings[i].Width() > t.Swings[j].Width()
+	})
+
+	return t, nil
+}
+
+// Grid returns the total monthly cost of base for every pair of values of
+// two inputs, indexed by the y value then the x value.
+func Grid(
+	ctx context.Context,
+	p firestore.PriceList,
+	base *Scenario,
+	x string,
+	xs []float64,
+	y string,
+	ys []float64,
+) ([][]firestore.Money, error) {
+	xin, err := LookupInput(x)
+	if err != nil {
+		return nil, err
+	}
+
+	yin, err := LookupInput(y)
+	if err != nil {
+		return nil, err
+	}
+
+	grid := make([][]firestore.Money, len(ys))
+	for i, yv := range ys {
+		s, err := yin.With(base, yv)
+		if err != nil {
+			return nil, err
+		}
+
+		grid[i] = make([]firestore.Money, len(xs))
+		for j, xv := range xs {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			if grid[i][j], err = total(ctx, p, xin, s, xv); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return grid, nil
+}
+
+// Return the total cost of s with in set to v.
+func total(ctx context.Context, p firestore.PriceList, in Input, s *Scenario, v float64) (firestore.Money, error) {
+	s, err := in.With(s, v)
+	if err != nil {
+		return 0, err
+	}
+
+	e, err := s.Estimate(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+
+	return e.Total(), nil
+}
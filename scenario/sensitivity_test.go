@@ -0,0 +1,65 @@
+package scenario_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+)
+
+func TestSensitivity(t *testing.T) {
+	ranges := []scenario.Range{
+		{Input: scenario.InputDeletes, Low: 0, Base: 1, High: 2},
+		{Input: scenario.InputPopulation, Low: 100000, Base: 1000000, High: 2000000},
+	}
+
+	tornado, err := scenario.Sensitivity(
+		context.Background(),
+		firestore.DefaultPrices,
+		scenario.Default(),
+		ranges,
+	)
+	if err != nil {
+		t.Fatalf("unable to analyze sensitivity: %v", err)
+	}
+
+	if got := tornado.Swings[0].Input; got != scenario.InputPopulation {
+		t.Errorf("want population ranked first, got %v", got)
+	}
+
+	// (1,000,000 * 10 * 2 - 20,000) / 100,000 * 0.02 * 30 = 119.892
+	if got, want := tornado.Swings[1].Width(), 11988*firestore.Cent; want != got {
+		t.Errorf("want deletes swing %v, got %v", want, got)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	base := scenario.Default()
+
+	grid, err := scenario.Grid(
+		context.Background(),
+		firestore.DefaultPrices,
+		base,
+		scenario.InputPopulation,
+		[]float64{1000, 1000000},
+		scenario.InputCount,
+		[]float64{5, 10, 20},
+	)
+	if err != nil {
+		t.Fatalf("unable to calculate grid: %v", err)
+	}
+
+	if len(grid) != 3 || len(grid[0]) != 2 {
+		t.Fatalf("want 3x2 grid, got %v", grid)
+	}
+
+	e, err := base.Estimate(context.Background(), firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate scenario: %v", err)
+	}
+
+	if want, got := e.Total(), grid[1][1]; want != got {
+		t.Errorf("want grid total at base inputs %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,144 @@
+package scenario
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/royge/gostcalc/firestore"
+)
+
+// MaxSolveValue is the largest input value Solve searches.
+const MaxSolveValue = 1 << 40
+
+// ErrOverBudget is returned when even the smallest input value costs more
+// than the budget.
+var ErrOverBudget = errors.New("over budget")
+
+// ErrUnbounded is returned when the budget is never reached.
+var ErrUnbounded = errors.New("budget is never reached")
+
+// Solution is the largest input value that keeps the total cost within a
+// budget.
+type Solution struct {
+	// Input is the name of the solved input.
+	Input string
+
+	// Value is the largest value within budget.
+	Value int64
+
+	// Estimate is the cost at Value.
+	Estimate Estimate
+
+	// Limiting is the category that grows most past Value, the one that
+	// hits the budget first.
+	Limiting Category
+}
+
+// Solve searches the largest value of input that keeps the total monthly cost
+// of base within budget. Every other input keeps its value in base. The
+// total cost must not decrease as the input grows.
+func Solve(ctx context.Context, p firestore.PriceList, base *Scenario, input string, budget firestore.Money) (*Solution, error) {
+	in, err := LookupInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if !in.Integer {
+		return nil, fmt.Errorf("input %s is not a whole number", input)
+	}
+
+	estimate := func(v int64) (Estimate, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		s, err := in.With(base, float64(v))
+		if err != nil {
+			return nil, err
+		}
+
+		return s.Estimate(ctx, p)
+	}
+
+	floor, limit := int64(in.Min), int64(MaxSolveValue)
+	if in.Max != 0 {
+		limit = int64(in.Max)
+	}
+
+	e, err := estimate(floor)
+	if err != nil {
+		return nil, err
+	}
+	if e.Total() > budget {
+		return nil, fmt.Errorf("%w: %s costs %v at %s %d", ErrOverBudget, base.Name, e.Total(), input, floor)
+	}
+
+	// Grow the upper bound until it exceeds the budget.
+	lo, hi := floor, floor+1
+	for {
+		e, err := estimate(hi)
+		if err != nil {
+			return nil, err
+		}
+		if e.Total() > budget {
+			break
+		}
+
+		lo = hi
+		if hi >= limit {
+			return nil, fmt.Errorf("%w by %s up to %d", ErrUnbounded, input, hi)
+		}
+
+		hi *= 2
+		if hi > limit {
+			hi = limit
+		}
+	}
+
+	// The largest value within budget is in [lo, hi).
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+
+		e, err := estimate(mid)
+		if err != nil {
+			return nil, err
+		}
+
+		if e.Total() > budget {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	at, err := estimate(lo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Single steps are lost in rounding, so compare with twice the value.
+	next := 2 * lo
+	if next < hi {
+		next = hi
+	}
+	if next > limit {
+		next = limit
+	}
+
+	past, err := estimate(next)
+	if err != nil {
+		return nil, err
+	}
+
+	sol := &Solution{Input: input, Value: lo, Estimate: at}
+	var growth firestore.Money = -1
+	for _, c := range Categories {
+		if g := past[c] - at[c]; g > growth {
+			growth = g
+			sol.Limiting = c
+		}
+	}
+
+	return sol, nil
+}
@@ -0,0 +1,76 @@
+package scenario_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+)
+
+func TestSolve(t *testing.T) {
+	ctx := context.Background()
+	base := scenario.Default()
+	budget := 500 * firestore.Dollar
+
+	sol, err := scenario.Solve(ctx, firestore.DefaultPrices, base, scenario.InputPopulation, budget)
+	if err != nil {
+		t.Fatalf("unable to solve population: %v", err)
+	}
+
+	if total := sol.Estimate.Total(); total > budget {
+		t.Errorf("want total within %v, got %v", budget, total)
+	}
+
+	next, err := scenario.LookupInput(scenario.InputPopulation)
+	if err != nil {
+		t.Fatalf("unable to lookup input: %v", err)
+	}
+
+	s, err := next.With(base, float64(sol.Value+1))
+	if err != nil {
+		t.Fatalf("unable to set population: %v", err)
+	}
+
+	e, err := s.Estimate(ctx, firestore.DefaultPrices)
+	if err != nil {
+		t.Fatalf("unable to estimate scenario: %v", err)
+	}
+
+	if e.Total() <= budget {
+		t.Errorf("want %d users over budget, got %v", sol.Value+1, e.Total())
+	}
+
+	if sol.Limiting != scenario.CategoryWrite {
+		t.Errorf("want writes to limit population, got %v", sol.Limiting)
+	}
+}
+
+func TestSolve_FreeTier(t *testing.T) {
+	base := scenario.Default()
+	base.Count = 1
+
+	sol, err := scenario.Solve(context.Background(), firestore.DefaultPrices, base, scenario.InputPopulation, 0)
+	if err != nil {
+		t.Fatalf("unable to solve population: %v", err)
+	}
+
+	// Deletes and writes run out of free quota first at 20,000 daily.
+	if sol.Value < 20000 {
+		t.Errorf("want at least the free quota of users, got %v", sol.Value)
+	}
+}
+
+func TestSolve_OverBudget(t *testing.T) {
+	_, err := scenario.Solve(
+		context.Background(),
+		firestore.DefaultPrices,
+		scenario.Default(),
+		scenario.InputIndexes,
+		firestore.Dollar,
+	)
+	if !errors.Is(err, scenario.ErrOverBudget) {
+		t.Errorf("want Solve() error %v, got %v", scenario.ErrOverBudget, err)
+	}
+}
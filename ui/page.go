@@ -0,0 +1,278 @@
+package ui
+
+// page is the estimate builder. It has no external assets.
+const page = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>gostcalc</title>
+<style>
+  body { font-family: system-ui, sans-serif; margin: 2em auto; max-width: 60em; color: #222; }
+  h1 { font-size: 1.4em; }
+  fieldset { border: 1px solid #ccc; margin-bottom: 1em; }
+  label { display: inline-block; margin: 0.25em 1em 0.25em 0; }
+  input[type=number] { width: 9em; }
+  table { border-collapse: collapse; }
+  th, td { padding: 0.25em 0.75em; border-bottom: 1px solid #eee; text-align: left; }
+  td.money, th.money { text-align: right; font-variant-numeric: tabular-nums; }
+  tr.total td { font-weight: bold; }
+  .error { color: #b00; }
+  button { margin: 0.25em 0.25em 0.25em 0; }
+</style>
+</head>
+<body>
+<h1>Firestore cost estimate</h1>
+
+<fieldset>
+  <legend>Workload</legend>
+  <label>Name <input id="name" value="scenario"></label>
+  <label>Active users <input id="population" type="number" min="0" value="1000000"></label>
+  <label>Daily transactions per user <input id="count" type="number" min="0" value="10"></label><br>
+  <label>Writes per transaction <input id="writes" type="number" min="0" step="0.1" value="1"></label>
+  <label>Reads per transaction <input id="reads" type="number" min="0" step="0.1" value="1"></label>
+  <label>Deletes per transaction <input id="deletes" type="number" min="0" step="0.1" value="1"></label>
+  <label>TTL days <input id="ttl_days" type="number" min="0" value="0"></label>
+</fieldset>
+
+<fieldset>
+  <legend>Document</legend>
+  <label>ID <input id="doc_id" value="AbCdEfGhIjKlMnOpQrSt"></label>
+  <label>Collection <input id="collection" size="40" value="qr-records"></label>
+  <table>
+    <thead><tr><th>Field</th><th>Type</th><th>Sample value</th><th>Indexed</th><th></th></tr></thead>
+    <tbody id="fields"></tbody>
+  </table>
+  <button id="add_field">Add field</button>
+  <p>Composite indexes, one per line with comma separated fields:</p>
+  <textarea id="composites" rows="3" cols="50"></textarea>
+</fieldset>
+
+<p>
+  <button id="download">Download scenario</button>
+  <label>Open scenario <input id="upload" type="file" accept=".json,application/json"></label>
+</p>
+
+<p id="error" class="error"></p>
+<table>
+  <thead><tr><th>Category</th><th class="money">Costs</th></tr></thead>
+  <tbody id="costs"></tbody>
+</table>
+<p id="meta"></p>
+
+<script>
+"use strict";
+
+const types = ["string", "integer", "float", "boolean", "timestamp"];
+const $ = (id) => document.getElementById(id);
+
+function addField(name, type, sample, indexed) {
+  const row = document.createElement("tr");
+
+  const cell = (el) => {
+    const td = document.createElement("td");
+    td.appendChild(el);
+    row.appendChild(td);
+    return el;
+  };
+
+  const n = cell(document.createElement("input"));
+  n.className = "field-name";
+  n.value = name || "";
+
+  const t = cell(document.createElement("select"));
+  t.className = "field-type";
+  for (const type of types) {
+    const o = document.createElement("option");
+    o.value = o.textContent = type;
+    t.appendChild(o);
+  }
+  t.value = type || "string";
+
+  const v = cell(document.createElement("input"));
+  v.className = "field-value";
+  v.value = sample === undefined ? "" : sample;
+
+  const i = cell(document.createElement("input"));
+  i.className = "field-indexed";
+  i.type = "checkbox";
+  i.checked = !!indexed;
+
+  const rm = cell(document.createElement("button"));
+  rm.textContent = "Remove";
+  rm.addEventListener("click", () => { row.remove(); update(); });
+
+  $("fields").appendChild(row);
+}
+
+function value(type, sample) {
+  switch (type) {
+  case "integer": return parseInt(sample, 10) || 0;
+  case "float": return parseFloat(sample) || 0;
+  case "boolean": return sample === "true";
+  case "timestamp": return new Date(Date.parse(sample) || Date.now()).toISOString().replace(/\.\d+Z$/, "Z");
+  default: return String(sample);
+  }
+}
+
+function typeOf(v) {
+  if (typeof v === "boolean") return "boolean";
+  if (typeof v === "number") return Number.isInteger(v) ? "integer" : "float";
+  if (typeof v === "string" && /^\d{4}-\d\d-\d\dT/.test(v) && !isNaN(Date.parse(v))) return "timestamp";
+  return "string";
+}
+
+function scenario() {
+  const data = {};
+  const single = [];
+  for (const row of $("fields").children) {
+    const name = row.querySelector(".field-name").value.trim();
+    if (!name) continue;
+
+    data[name] = value(row.querySelector(".field-type").value, row.querySelector(".field-value").value);
+    if (row.querySelector(".field-indexed").checked) single.push({[name]: data[name]});
+  }
+
+  const composite = [];
+  for (const line of $("composites").value.split("\n")) {
+    const index = {};
+    for (const f of line.split(",").map((f) => f.trim()).filter((f) => f)) {
+      index[f] = f in data ? data[f] : "";
+    }
+    if (Object.keys(index).length) composite.push(index);
+  }
+
+  const num = (id) => Number($(id).value) || 0;
+
+  return {
+    name: $("name").value,
+    population: Math.round(num("population")),
+    count: Math.round(num("count")),
+    writes: num("writes"),
+    reads: num("reads"),
+    deletes: num("deletes"),
+    ttl_days: Math.round(num("ttl_days")),
+    document: {
+      id: $("doc_id").value,
+      collection: $("collection").value,
+      data: data,
+      single_field_indexes: single,
+      composite_indexes: composite
+    }
+  };
+}
+
+function load(s) {
+  for (const k of ["name", "population", "count", "writes", "reads", "deletes", "ttl_days"]) {
+    if (s[k] !== undefined) $(k).value = s[k];
+  }
+
+  const doc = s.document || {};
+  $("doc_id").value = doc.id || "";
+  $("collection").value = doc.collection || "";
+
+  const indexed = {};
+  for (const idx of doc.single_field_indexes || []) {
+    for (const f of Object.keys(idx)) indexed[f] = true;
+  }
+
+  $("fields").innerHTML = "";
+  for (const [name, v] of Object.entries(doc.data || {})) {
+    addField(name, typeOf(v), typeof v === "object" ? JSON.stringify(v) : v, indexed[name]);
+  }
+
+  $("composites").value = (doc.composite_indexes || []).map((idx) => Object.keys(idx).join(", ")).join("\n");
+  update();
+}
+
+let pending = 0;
+
+async function update() {
+  const id = ++pending;
+  const body = JSON.stringify(scenario());
+
+  try {
+    const [estimate, size] = await Promise.all([
+      post("/v1/estimate", body),
+      post("/v1/document/size", JSON.stringify(JSON.parse(body).document))
+    ]);
+    if (id !== pending) return;
+
+    $("error").textContent = "";
+    const rows = Object.entries(estimate.costs).map(([c, v]) => [c, v]);
+    rows.push(["Total", estimate.total]);
+
+    $("costs").innerHTML = "";
+    for (const [c, v] of rows) {
+      const tr = document.createElement("tr");
+      if (c === "Total") tr.className = "total";
+
+      const name = document.createElement("td");
+      name.textContent = c;
+      const cost = document.createElement("td");
+      cost.className = "money";
+      cost.textContent = v;
+
+      tr.append(name, cost);
+      $("costs").appendChild(tr);
+    }
+
+    const cur = estimate.currency;
+    $("meta").textContent = "Monthly costs in " + cur.code +
+      (cur.rate ? " (rate " + cur.rate + " as of " + cur.rate_date + ")" : " (list prices)") +
+      ". Document size: " + size.total + " bytes.";
+  } catch (err) {
+    if (id === pending) $("error").textContent = err.message;
+  }
+}
+
+async function post(path, body) {
+  const res = await fetch(path, {method: "POST", headers: {"Content-Type": "application/json"}, body: body});
+  const json = await res.json();
+  if (!res.ok) throw new Error(json.error || res.statusText);
+  return json;
+}
+
+$("add_field").addEventListener("click", () => { addField(); update(); });
+document.addEventListener("input", (e) => { if (e.target.id !== "upload") update(); });
+document.addEventListener("change", (e) => { if (e.target.id !== "upload") update(); });
+
+$("download").addEventListener("click", () => {
+  const s = scenario();
+  const a = document.createElement("a");
+  a.href = URL.createObjectURL(new Blob([JSON.stringify(s, null, 2)], {type: "application/json"}));
+  a.download = (s.name || "scenario") + ".json";
+  a.click();
+  URL.revokeObjectURL(a.href);
+});
+
+$("upload").addEventListener("change", async (e) => {
+  const file = e.target.files[0];
+  if (!file) return;
+
+  try {
+    load(JSON.parse(await file.text()));
+  } catch (err) {
+    $("error").textContent = "Unable to open scenario: " + err.message;
+  }
+});
+
+load({
+  name: "qr-records",
+  document: {
+    id: "AbCdEfGhIjKlMnOpQrSt",
+    collection: "prod-qr/AbCdEfGhIjKlMnOpQrSt/qr-records",
+    data: {
+      merchant_id: "AbCdEfGhIjKlMnOpQrSt",
+      profile_qr_id: "AbCdEfGhIjKlMnOpQrSt",
+      date_created: "2026-01-01T00:00:00Z",
+      type: 1
+    },
+    single_field_indexes: [{date_created: "2026-01-01T00:00:00Z"}],
+    composite_indexes: [{merchant_id: "", date_created: ""}]
+  }
+});
+</script>
+</body>
+</html>
+`
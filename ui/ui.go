@@ -0,0 +1,38 @@
+// Package ui serves a single-page estimate builder for the browser.
+package ui
+
+import (
+	"net/http"
+
+	"github.com/royge/gostcalc/api"
+)
+
+// Handler returns the HTTP handler of the page and the API it uses.
+func Handler(s *api.Server) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", s.Handler())
+	mux.HandleFunc("/", index)
+
+	return mux
+}
+
+func index(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set(
+		"Content-Security-Policy",
+		"default-src 'none'; script-src 'unsafe-inline'; style-src 'unsafe-inline'; connect-src 'self'",
+	)
+
+	_, _ = w.Write([]byte(page))
+}
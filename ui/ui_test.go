@@ -0,0 +1,58 @@
+package ui_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/royge/gostcalc/api"
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/ui"
+)
+
+func TestHandler(t *testing.T) {
+	h := ui.Handler(&api.Server{Prices: firestore.DefaultPrices})
+
+	tt := []struct {
+		name        string
+		method      string
+		path        string
+		body        string
+		want        int
+		contentType string
+	}{
+		{"page", http.MethodGet, "/", "", http.StatusOK, "text/html; charset=utf-8"},
+		{"api", http.MethodPost, "/v1/estimate", "{}", http.StatusOK, "application/json"},
+		{"not found", http.MethodGet, "/app.js", "", http.StatusNotFound, ""},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body)))
+
+			if rec.Code != tc.want {
+				t.Fatalf("want status %v, got %v", tc.want, rec.Code)
+			}
+
+			if got := rec.Header().Get("Content-Type"); tc.contentType != "" && got != tc.contentType {
+				t.Errorf("want content type %v, got %v", tc.contentType, got)
+			}
+		})
+	}
+}
+
+func TestHandler_NoExternalAssets(t *testing.T) {
+	rec := httptest.NewRecorder()
+	ui.Handler(&api.Server{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	body := rec.Body.String()
+	for _, external := range []string{"http://", "https://", "src=\"//"} {
+		if strings.Contains(body, external) {
+			t.Errorf("want no external assets, found %q", external)
+		}
+	}
+}
@@ -0,0 +1,370 @@
+// Package usage prices measured Firestore usage from Cloud Monitoring metric
+// exports.
+package usage
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+)
+
+// Metric types of the usage that is priced.
+const (
+	MetricReads   = "firestore.googleapis.com/document/read_count"
+	MetricWrites  = "firestore.googleapis.com/document/write_count"
+	MetricDeletes = "firestore.googleapis.com/document/delete_count"
+
+	// MetricStorage is the stored bytes of data and indexes.
+	MetricStorage = "firestore.googleapis.com/storage/data_and_index_storage_bytes"
+)
+
+// DateLayout is the layout of day dates.
+const DateLayout = "2006-01-02"
+
+// ErrFormat is returned for metric files that cannot be read.
+var ErrFormat = errors.New("invalid metric file")
+
+// Sample is a point of a metric time series.
+type Sample struct {
+	Metric string
+	Time   time.Time
+	Value  float64
+}
+
+// ReadCSV reads samples from CSV with metric, time and value columns. The
+// header names the columns, other columns are ignored.
+func ReadCSV(r io.Reader) ([]Sample, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFormat, err)
+	}
+
+	cols := map[string]int{}
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, c := range []string{"metric", "time", "value"} {
+		if _, ok := cols[c]; !ok {
+			return nil, fmt.Errorf("%w: missing %s column", ErrFormat, c)
+		}
+	}
+
+	var samples []Sample
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return samples, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrFormat, err)
+		}
+
+		cell := func(c string) string {
+			if cols[c] >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[cols[c]])
+		}
+
+		t, err := time.Parse(time.RFC3339, cell("time"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrFormat, line, err)
+		}
+
+		v, err := strconv.ParseFloat(cell("value"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrFormat, line, err)
+		}
+
+		samples = append(samples, Sample{Metric: cell("metric"), Time: t, Value: v})
+	}
+}
+
+// timeSeries is a time series of the Cloud Monitoring API.
+type timeSeries struct {
+	Metric struct {
+		Type string `json:"type"`
+	} `json:"metric"`
+	Points []struct {
+		Interval struct {
+			StartTime string `json:"startTime"`
+			EndTime   string `json:"endTime"`
+		} `json:"interval"`
+		Value struct {
+			Int64Value  *json.Number `json:"int64Value"`
+			DoubleValue *float64     `json:"doubleValue"`
+		} `json:"value"`
+	} `json:"points"`
+}
+
+// ReadJSON reads samples from a Cloud Monitoring timeSeries.list response, or
+// an array of its time series. Points are dated by the start of their
+// interval.
+func ReadJSON(r io.Reader) ([]Sample, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var series []timeSeries
+	if err := json.Unmarshal(b, &series); err != nil {
+		var list struct {
+			TimeSeries []timeSeries `json:"timeSeries"`
+		}
+		if err := json.Unmarshal(b, &list); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrFormat, err)
+		}
+		series = list.TimeSeries
+	}
+
+	var samples []Sample
+	for _, ts := range series {
+		for _, p := range ts.Points {
+			at := p.Interval.StartTime
+			if at == "" {
+				at = p.Interval.EndTime
+			}
+
+			t, err := time.Parse(time.RFC3339Nano, at)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrFormat, err)
+			}
+
+			var v float64
+			switch {
+			case p.Value.Int64Value != nil:
+				if v, err = p.Value.Int64Value.Float64(); err != nil {
+					return nil, fmt.Errorf("%w: %v", ErrFormat, err)
+				}
+			case p.Value.DoubleValue != nil:
+				v = *p.Value.DoubleValue
+			default:
+				return nil, fmt.Errorf("%w: point of %s without value", ErrFormat, ts.Metric.Type)
+			}
+
+			samples = append(samples, Sample{Metric: ts.Metric.Type, Time: t, Value: v})
+		}
+	}
+
+	return samples, nil
+}
+
+// Load reads the samples of a metric file, JSON for the .json extension and
+// CSV otherwise.
+func Load(path string) ([]Sample, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	read := ReadCSV
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		read = ReadJSON
+	}
+
+	samples, err := read(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return samples, nil
+}
+
+// Day is the usage of a day of the free quota, midnight to midnight Pacific
+// Time.
+type Day struct {
+	Date time.Time
+
+	Reads   int64
+	Writes  int64
+	Deletes int64
+
+	// StorageBytes is the mean stored bytes of the day.
+	StorageBytes int64
+}
+
+// Usage is the measured usage by day.
+type Usage struct {
+	Days []*Day
+
+	// Ignored counts the samples of metrics that are not priced.
+	Ignored map[string]int
+}
+
+// Aggregate sums the operation counts and averages the stored bytes of
+// samples by Pacific Time day, the day of the free quota. Stored bytes of
+// several series sampled at the same time are summed before averaging.
+func Aggregate(samples []Sample) *Usage {
+	u := &Usage{Ignored: map[string]int{}}
+	loc := firestore.QuotaLocation()
+
+	days := map[string]*Day{}
+	// Stored bytes by date and sample time.
+	storage := map[string]map[int64]float64{}
+
+	for _, s := range samples {
+		switch s.Metric {
+		case MetricReads, MetricWrites, MetricDeletes, MetricStorage:
+		default:
+			u.Ignored[s.Metric]++
+			continue
+		}
+
+		date := s.Time.In(loc).Format(DateLayout)
+
+		d, ok := days[date]
+		if !ok {
+			t, _ := time.ParseInLocation(DateLayout, date, loc)
+			d = &Day{Date: t}
+			days[date] = d
+		}
+
+		switch s.Metric {
+		case MetricReads:
+			d.Reads += int64(math.Round(s.Value))
+		case MetricWrites:
+			d.Writes += int64(math.Round(s.Value))
+		case MetricDeletes:
+			d.Deletes += int64(math.Round(s.Value))
+		case MetricStorage:
+			if storage[date] == nil {
+				storage[date] = map[int64]float64{}
+			}
+			storage[date][s.Time.UnixNano()] += s.Value
+		}
+	}
+
+	for date, totals := range storage {
+		var sum float64
+		for _, v := range totals {
+			sum += v
+		}
+		days[date].StorageBytes = int64(math.Round(sum / float64(len(totals))))
+	}
+
+	for _, d := range days {
+		u.Days = append(u.Days, d)
+	}
+	sort.Slice(u.Days, func(i, j int) bool { return u.Days[i].Date.Before(u.Days[j].Date) })
+
+	// Storage is sampled less often than operations, days without samples
+	// keep the stored bytes of the day before.
+	for i, d := range u.Days {
+		if _, ok := storage[d.Date.Format(DateLayout)]; !ok && i > 0 {
+			d.StorageBytes = u.Days[i-1].StorageBytes
+		}
+	}
+
+	return u
+}
+
+// Cost prices the operations of the day after the daily free tier, and the
+// day's share of a month of storage after the free storage.
+func (d *Day) Cost(ctx context.Context, p firestore.PriceList) (scenario.Estimate, error) {
+	amounts, err := d.amounts(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	e := scenario.Estimate{}
+	for c, a := range amounts {
+		e[c] = firestore.Bill(a)
+	}
+
+	return e, nil
+}
+
+// Exact cost of every category of the day in micro-units, before rounding.
+func (d *Day) amounts(ctx context.Context, p firestore.PriceList) (map[scenario.Category]*big.Rat, error) {
+	ops := []struct {
+		category scenario.Category
+		calc     firestore.ExactCalculator
+		count    int64
+	}{
+		{scenario.CategoryWrite, &firestore.DailyWriteCalculator{Price: p.Write}, d.Writes},
+		{scenario.CategoryRead, &firestore.DailyReadCalculator{Price: p.Read}, d.Reads},
+		{scenario.CategoryDelete, &firestore.DailyDeleteCalculator{Price: p.Delete}, d.Deletes},
+	}
+
+	amounts := map[scenario.Category]*big.Rat{}
+	for _, op := range ops {
+		a, err := op.calc.Exact(ctx, big.NewInt(op.count))
+		if err != nil {
+			return nil, fmt.Errorf("unable to calculate %s cost: %w", op.category, err)
+		}
+		amounts[op.category] = a
+	}
+
+	billable := big.NewInt(d.StorageBytes - firestore.MonthlyFreeStorage)
+	amounts[scenario.CategoryStorage] = firestore.Amount(
+		billable,
+		p.Storage,
+		firestore.OneGB*firestore.MonthNumOfDays,
+	)
+
+	return amounts, nil
+}
+
+// Cost returns the cost of the days, the exact amounts of every day summed
+// and rounded once per category.
+func (u *Usage) Cost(ctx context.Context, p firestore.PriceList) (scenario.Estimate, error) {
+	totals := map[scenario.Category]*big.Rat{}
+
+	for _, d := range u.Days {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		amounts, err := d.amounts(ctx, p)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", d.Date.Format(DateLayout), err)
+		}
+
+		for c, a := range amounts {
+			if totals[c] == nil {
+				totals[c] = new(big.Rat)
+			}
+			totals[c].Add(totals[c], a)
+		}
+	}
+
+	total := scenario.Estimate{}
+	for c, a := range totals {
+		total[c] = firestore.Bill(a)
+	}
+
+	return total, nil
+}
+
+// Prorate scales a monthly estimate to days.
+func Prorate(e scenario.Estimate, days int) scenario.Estimate {
+	out := scenario.Estimate{}
+	for c, m := range e {
+		r := new(big.Rat).SetFrac64(
+			int64(m)*int64(days),
+			firestore.MonthNumOfDays*int64(firestore.Dollar),
+		)
+		out[c] = firestore.RatMoney(r).Round(firestore.BillingUnit, firestore.BillingRounding)
+	}
+
+	return out
+}
@@ -0,0 +1,224 @@
+package usage_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/royge/gostcalc/firestore"
+	"github.com/royge/gostcalc/scenario"
+	"github.com/royge/gostcalc/usage"
+)
+
+const metricsCSV = `metric,time,value,database
+firestore.googleapis.com/document/read_count,2026-03-01T08:00:00Z,40000,(default)
+firestore.googleapis.com/document/read_count,2026-03-01T20:00:00Z,160000,(default)
+firestore.googleapis.com/document/write_count,2026-03-01T20:00:00Z,120000,(default)
+firestore.googleapis.com/document/delete_count,2026-03-01T20:00:00Z,10000,(default)
+firestore.googleapis.com/storage/data_and_index_storage_bytes,2026-03-01T08:00:00Z,4073741824,(default)
+firestore.googleapis.com/storage/data_and_index_storage_bytes,2026-03-01T20:00:00Z,5073741824,(default)
+firestore.googleapis.com/document/read_count,2026-03-02T16:00:00+08:00,30000,(default)
+firestore.googleapis.com/api/request_count,2026-03-02T00:00:00Z,7,(default)
+`
+
+const metricsJSON = `{
+  "timeSeries": [
+    {
+      "metric": {"type": "firestore.googleapis.com/document/read_count"},
+      "points": [
+        {
+          "interval": {"startTime": "2026-03-01T20:00:00Z", "endTime": "2026-03-01T20:01:00Z"},
+          "value": {"int64Value": "200000"}
+        }
+      ]
+    },
+    {
+      "metric": {"type": "firestore.googleapis.com/storage/data_and_index_storage_bytes"},
+      "points": [
+        {
+          "interval": {"endTime": "2026-03-01T08:00:00Z"},
+          "value": {"doubleValue": 4573741824}
+        }
+      ]
+    }
+  ]
+}`
+
+func TestAggregate(t *testing.T) {
+	samples, err := usage.ReadCSV(strings.NewReader(metricsCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u := usage.Aggregate(samples)
+
+	if len(u.Days) != 2 {
+		t.Fatalf("want 2 days, got %v", len(u.Days))
+	}
+
+	d := u.Days[0]
+	if d.Date.Format(usage.DateLayout) != "2026-03-01" {
+		t.Errorf("want 2026-03-01, got %v", d.Date)
+	}
+	if d.Reads != 200000 || d.Writes != 120000 || d.Deletes != 10000 {
+		t.Errorf("want 200000 reads, 120000 writes and 10000 deletes, got %+v", d)
+	}
+	if d.StorageBytes != 4573741824 {
+		t.Errorf("want mean storage 4573741824, got %v", d.StorageBytes)
+	}
+
+	// 16:00 at +08:00 is midnight Pacific Time of March 2.
+	if u.Days[1].Reads != 30000 {
+		t.Errorf("want 30000 reads on March 2, got %v", u.Days[1].Reads)
+	}
+	if u.Days[1].StorageBytes != d.StorageBytes {
+		t.Errorf("want storage of March 1 kept on March 2, got %v", u.Days[1].StorageBytes)
+	}
+
+	if u.Ignored["firestore.googleapis.com/api/request_count"] != 1 {
+		t.Errorf("want 1 ignored sample, got %v", u.Ignored)
+	}
+}
+
+func TestAggregate_StorageSeries(t *testing.T) {
+	samples, err := usage.ReadCSV(strings.NewReader(`metric,time,value,database
+firestore.googleapis.com/storage/data_and_index_storage_bytes,2026-03-01T08:00:00Z,1000,(default)
+firestore.googleapis.com/storage/data_and_index_storage_bytes,2026-03-01T08:00:00Z,500,logs
+firestore.googleapis.com/storage/data_and_index_storage_bytes,2026-03-01T20:00:00Z,3000,(default)
+firestore.googleapis.com/storage/data_and_index_storage_bytes,2026-03-01T20:00:00Z,500,logs
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u := usage.Aggregate(samples)
+	if len(u.Days) != 1 {
+		t.Fatalf("want 1 day, got %v", len(u.Days))
+	}
+
+	// The mean of the totals 1500 and 3500 of both series.
+	if got := u.Days[0].StorageBytes; got != 2500 {
+		t.Errorf("want mean storage 2500, got %v", got)
+	}
+}
+
+func TestReadJSON(t *testing.T) {
+	samples, err := usage.ReadJSON(strings.NewReader(metricsJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u := usage.Aggregate(samples)
+	if len(u.Days) != 1 || u.Days[0].Reads != 200000 || u.Days[0].StorageBytes != 4573741824 {
+		t.Errorf("unexpected usage %+v", u.Days[0])
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	tt := []struct {
+		name string
+		read func() ([]usage.Sample, error)
+	}{
+		{"missing column", func() ([]usage.Sample, error) {
+			return usage.ReadCSV(strings.NewReader("metric,value\n"))
+		}},
+		{"bad time", func() ([]usage.Sample, error) {
+			return usage.ReadCSV(strings.NewReader("metric,time,value\nx,yesterday,1\n"))
+		}},
+		{"bad json", func() ([]usage.Sample, error) {
+			return usage.ReadJSON(strings.NewReader("{"))
+		}},
+		{"no value", func() ([]usage.Sample, error) {
+			return usage.ReadJSON(strings.NewReader(
+				`[{"metric": {"type": "x"}, "points": [{"interval": {"endTime": "2026-03-01T08:00:00Z"}}]}]`,
+			))
+		}},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.read(); !errors.Is(err, usage.ErrFormat) {
+				t.Errorf("want ErrFormat, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCost(t *testing.T) {
+	samples, err := usage.ReadCSV(strings.NewReader(metricsCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := usage.Aggregate(samples).Cost(context.Background(), firestore.DefaultPrices)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := scenario.Estimate{
+		// 100,000 writes above the free tier on March 1.
+		scenario.CategoryWrite: 18 * firestore.Cent,
+		// 150,000 reads above the free tier on March 1, none on March 2.
+		scenario.CategoryRead: 9 * firestore.Cent,
+		// Deletes within the free tier.
+		scenario.CategoryDelete: 0,
+		// Two days of 3.5 billable GB, each 3.5 * 0.18 / 30 = 0.021,
+		// rounded once.
+		scenario.CategoryStorage: 4 * firestore.Cent,
+	}
+
+	for _, c := range []scenario.Category{
+		scenario.CategoryWrite,
+		scenario.CategoryRead,
+		scenario.CategoryDelete,
+		scenario.CategoryStorage,
+	} {
+		if got[c] != want[c] {
+			t.Errorf("want %v cost %v, got %v", c, want[c], got[c])
+		}
+	}
+}
+
+func TestCost_Month(t *testing.T) {
+	u := &usage.Usage{}
+	for i := 0; i < firestore.MonthNumOfDays; i++ {
+		u.Days = append(u.Days, &usage.Day{
+			Date:         time.Date(2026, 4, i+1, 0, 0, 0, 0, time.UTC),
+			Reads:        50001,
+			StorageBytes: 2000000000,
+		})
+	}
+
+	got, err := u.Cost(context.Background(), firestore.DefaultPrices)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 1 read a day above the free tier bills 0.0000006 a day, nothing a
+	// month.
+	if got[scenario.CategoryRead] != 0 {
+		t.Errorf("want Read cost 0.00, got %v", got[scenario.CategoryRead])
+	}
+
+	// (2,000,000,000 - 1,073,741,824) / 1,000,000,000 * 0.18 = 0.1667...
+	if want := 17 * firestore.Cent; got[scenario.CategoryStorage] != want {
+		t.Errorf("want Storage cost %v, got %v", want, got[scenario.CategoryStorage])
+	}
+}
+
+func TestProrate(t *testing.T) {
+	e := scenario.Estimate{scenario.CategoryWrite: 30 * firestore.Dollar, scenario.CategoryRead: 1 * firestore.Cent}
+
+	got := usage.Prorate(e, 7)
+
+	if got[scenario.CategoryWrite] != 7*firestore.Dollar {
+		t.Errorf("want 7.00, got %v", got[scenario.CategoryWrite])
+	}
+	if got[scenario.CategoryRead] != 0 {
+		t.Errorf("want 0.00, got %v", got[scenario.CategoryRead])
+	}
+}